@@ -156,6 +156,27 @@ func (d deleteDataLogic) deleteAppOrgUsersData(appID string, orgID string, accou
 		return
 	}
 
+	// delete poll templates
+	err = d.storage.DeletePollTemplatesWithAccountIDs(orgID, accountsIDs)
+	if err != nil {
+		d.logger.Errorf("error deleting the poll templates - %s", err)
+		return
+	}
+
+	// delete poll sessions
+	err = d.storage.DeletePollSessionsWithAccountIDs(orgID, accountsIDs)
+	if err != nil {
+		d.logger.Errorf("error deleting the poll sessions - %s", err)
+		return
+	}
+
+	// delete quiz scores
+	err = d.storage.DeleteQuizScoresWithAccountIDs(orgID, accountsIDs)
+	if err != nil {
+		d.logger.Errorf("error deleting the quiz scores - %s", err)
+		return
+	}
+
 	// delete survey responses
 	err = d.storage.DeleteSurveyResponsesWithIDs(appID, orgID, accountsIDs)
 	if err != nil {
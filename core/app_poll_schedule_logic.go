@@ -0,0 +1,174 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"polls/core/model"
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
+)
+
+const (
+	// the longest time the scheduler sleeps before checking the storage again
+	pollScheduleMaxWait = time.Hour
+	// the shortest time the scheduler sleeps, protects from busy looping on failures
+	pollScheduleMinWait = time.Second
+	// the time the scheduler waits before retrying when the storage fails
+	pollScheduleRetryWait = 30 * time.Second
+)
+
+// pollScheduleLogic starts and ends the polls which have start_at/end_at times, and creates the instances of the recurring polls.
+// The due transitions are always recomputed from the storage, so nothing is lost on restart.
+type pollScheduleLogic struct {
+	logger logs.Logger
+
+	app *Application
+
+	//wakes up the timer when a poll schedule may have changed
+	rescheduleChan chan bool
+}
+
+func (p pollScheduleLogic) start() {
+	go p.run()
+}
+
+func (p pollScheduleLogic) run() {
+	p.logger.Info("Poll schedule timer")
+
+	for {
+		p.process(time.Now().UTC())
+
+		duration := p.nextDuration()
+		p.logger.Infof("pollScheduleLogic -> next call after %s", duration)
+
+		timer := time.NewTimer(duration)
+		select {
+		case <-timer.C:
+			p.logger.Info("pollScheduleLogic -> timer expired")
+		case <-p.rescheduleChan:
+			p.logger.Info("pollScheduleLogic -> reschedule")
+			timer.Stop()
+		}
+	}
+}
+
+// reschedule makes the scheduler recompute the next due time. It never blocks.
+func (p pollScheduleLogic) reschedule() {
+	select {
+	case p.rescheduleChan <- true:
+	default:
+		// already requested
+	}
+}
+
+func (p pollScheduleLogic) nextDuration() time.Duration {
+	next, err := p.app.storage.GetNextPollScheduleTime()
+	if err != nil {
+		p.logger.Errorf("error on getting next poll schedule time - %s", err)
+		return pollScheduleRetryWait
+	}
+	if next == nil {
+		return pollScheduleMaxWait
+	}
+
+	duration := time.Until(*next)
+	if duration < pollScheduleMinWait {
+		return pollScheduleMinWait
+	}
+	if duration > pollScheduleMaxWait {
+		return pollScheduleMaxWait
+	}
+	return duration
+}
+
+func (p pollScheduleLogic) process(now time.Time) {
+	//start polls
+	pollsToStart, err := p.app.storage.GetScheduledPollsToStart(now)
+	if err != nil {
+		p.logger.Errorf("error on loading polls to start - %s", err)
+	}
+	for _, poll := range pollsToStart {
+		updated, err := p.changePollStatus(poll, model.PollActionStart)
+		if err != nil {
+			p.logger.Errorf("error on starting poll %s - %s", poll.ID.Hex(), err)
+			continue
+		}
+		if !updated {
+			// already started by someone else
+			continue
+		}
+
+		p.logger.Infof("scheduled start of poll %s", poll.ID.Hex())
+		poll.Status = model.PollStatusStarted
+		p.app.onPollStarted(nil, &poll)
+	}
+
+	//end polls
+	pollsToEnd, err := p.app.storage.GetScheduledPollsToEnd(now)
+	if err != nil {
+		p.logger.Errorf("error on loading polls to end - %s", err)
+	}
+	for _, poll := range pollsToEnd {
+		updated, err := p.changePollStatus(poll, model.PollActionEnd)
+		if err != nil {
+			p.logger.Errorf("error on ending poll %s - %s", poll.ID.Hex(), err)
+			continue
+		}
+		if !updated {
+			// already ended by someone else
+			continue
+		}
+
+		p.logger.Infof("scheduled end of poll %s", poll.ID.Hex())
+		poll.Status = model.PollStatusTerminated
+		p.app.onPollEnded(nil, &poll)
+	}
+
+	//create the instances of the recurring polls
+	recurringPolls, err := p.app.storage.GetRecurringPollsDue(now)
+	if err != nil {
+		p.logger.Errorf("error on loading recurring polls - %s", err)
+	}
+	for _, poll := range recurringPolls {
+		instance, err := p.app.createPollInstance(poll, now)
+		if err != nil {
+			p.logger.Errorf("error on creating an instance of poll %s - %s", poll.ID.Hex(), err)
+			continue
+		}
+		if instance == nil {
+			// already created by someone else
+			continue
+		}
+
+		p.logger.Infof("scheduled instance %s of poll %s", instance.ID.Hex(), poll.ID.Hex())
+	}
+}
+
+// changePollStatus applies the scheduled action to the poll status. Returns false if the poll has already been changed by someone else.
+func (p pollScheduleLogic) changePollStatus(poll model.Poll, action string) (bool, error) {
+	transition, err := model.NewPollStatusTransition(nil, poll.Status, action)
+	if err != nil {
+		return false, err
+	}
+	transition.Reason = model.PollTransitionReasonSchedule
+	return p.app.storage.UpdatePollStatus(poll, *transition)
+}
+
+// newPollScheduleLogic creates new pollScheduleLogic
+func newPollScheduleLogic(app *Application, logger logs.Logger) pollScheduleLogic {
+	rescheduleChan := make(chan bool, 1)
+	return pollScheduleLogic{app: app, rescheduleChan: rescheduleChan, logger: logger}
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"polls/core/model"
+	"sync"
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
+)
+
+const (
+	// the time the requests are collected before the tallies are recalculated, a poll is recalculated once per batch
+	pollTallyDelay = time.Second
+	// the time the tally logic waits before retrying when the storage fails
+	pollTallyRetryWait = 30 * time.Second
+)
+
+// pollTallyLogic recalculates the instant-runoff tallies of the ranked polls and the results of the open text polls off the vote path.
+// The requests are debounced, the storage skips a tally which is already up to date with the poll counters revision.
+// The stale tallies are loaded from the storage on start, so nothing is lost on restart.
+type pollTallyLogic struct {
+	logger logs.Logger
+
+	app *Application
+
+	//the polls waiting for a tally, poll id -> org id
+	pending      map[string]string
+	pendingMutex *sync.Mutex
+
+	//wakes up the tally logic when a poll is added
+	wakeChan chan bool
+}
+
+func (p pollTallyLogic) start() {
+	go p.run()
+}
+
+func (p pollTallyLogic) run() {
+	p.logger.Info("Poll tally")
+
+	polls, err := p.app.storage.GetPollsWithStaleTally()
+	if err != nil {
+		p.logger.Errorf("error on loading polls with stale tally - %s", err)
+	}
+	for _, poll := range polls {
+		p.request(&poll)
+	}
+
+	for {
+		<-p.wakeChan
+		time.Sleep(pollTallyDelay)
+		p.process()
+	}
+}
+
+// request adds the poll to the polls waiting for a tally. It never blocks.
+func (p pollTallyLogic) request(poll *model.Poll) {
+	p.add(poll.ID.Hex(), poll.OrgID)
+}
+
+func (p pollTallyLogic) add(pollID string, orgID string) {
+	p.pendingMutex.Lock()
+	p.pending[pollID] = orgID
+	p.pendingMutex.Unlock()
+
+	select {
+	case p.wakeChan <- true:
+	default:
+		// already requested
+	}
+}
+
+func (p pollTallyLogic) process() {
+	p.pendingMutex.Lock()
+	pending := make(map[string]string, len(p.pending))
+	for pollID, orgID := range p.pending {
+		pending[pollID] = orgID
+		delete(p.pending, pollID)
+	}
+	p.pendingMutex.Unlock()
+
+	failed := map[string]string{}
+	for pollID, orgID := range pending {
+		err := p.app.storage.UpdatePollTally(orgID, pollID)
+		if err != nil {
+			p.logger.Errorf("error on updating the tally of poll %s - %s", pollID, err)
+			failed[pollID] = orgID
+		}
+	}
+
+	if len(failed) > 0 {
+		time.AfterFunc(pollTallyRetryWait, func() {
+			for pollID, orgID := range failed {
+				p.add(pollID, orgID)
+			}
+		})
+	}
+}
+
+// newPollTallyLogic creates new pollTallyLogic
+func newPollTallyLogic(app *Application, logger logs.Logger) pollTallyLogic {
+	wakeChan := make(chan bool, 1)
+	return pollTallyLogic{app: app, pending: map[string]string{}, pendingMutex: &sync.Mutex{}, wakeChan: wakeChan, logger: logger}
+}
@@ -45,12 +45,16 @@ type Application struct {
 	serviceID       string
 	corebb          *corebb.Adapter
 	deleteDataLogic deleteDataLogic
+	pollSchedule    pollScheduleLogic
+	pollTally       pollTallyLogic
 }
 
 // Start starts the core part of the application
 func (app *Application) Start() {
 	app.storage.SetListener(app)
 	app.deleteDataLogic.start()
+	app.pollSchedule.start()
+	app.pollTally.start()
 }
 
 // NewApplication creates new Application
@@ -71,6 +75,9 @@ func NewApplication(version string, build string, storage Storage, cacheAdapter
 		deleteDataLogic: deleteDataLogic,
 	}
 
+	application.pollSchedule = newPollScheduleLogic(&application, *logger)
+	application.pollTally = newPollTallyLogic(&application, *logger)
+
 	// add the drivers ports/interfaces
 	application.Services = &servicesImpl{app: &application}
 
@@ -19,6 +19,8 @@ import (
 	"polls/driven/groups"
 	"polls/driven/storage"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // Services exposes APIs for the driver adapters
@@ -27,19 +29,32 @@ type Services interface {
 
 	// CRUD Polls
 	GetPolls(user *model.User, filter model.PollsFilter, filterByToMembers bool) ([]model.Poll, error)
+	GetPollsPage(user *model.User, filter model.PollsFilter, filterByToMembers bool) ([]model.Poll, *string, error)
 	GetPoll(user *model.User, id string) (*model.Poll, error)
+	GetPollByPin(user *model.User, pin int) (*model.Poll, error)
 	CreatePoll(user *model.User, poll model.Poll) (*model.Poll, error)
-	UpdatePoll(user *model.User, poll model.Poll) (*model.Poll, error)
-	DeletePoll(user *model.User, id string) error
+	UpdatePoll(user *model.User, poll model.Poll, admin bool) (*model.Poll, error)
+	DeletePoll(user *model.User, id string, admin bool) error
 	DeletePollsWithGroupID(user *model.User, groupID string) error
+	ClonePoll(user *model.User, pollID string, request model.PollCloneRequest) (*model.Poll, error)
+	GetPollSeries(user *model.User, id string) (*model.PollSeries, error)
+	AddPollCoOwner(user *model.User, pollID string, userID string) (*model.Poll, error)
+	RemovePollCoOwner(user *model.User, pollID string, userID string) (*model.Poll, error)
 
 	VotePoll(user *model.User, pollID string, vote model.PollVote) error
+	RetractPollVote(user *model.User, pollID string) error
+	ExportPollResults(user *model.User, pollID string, writer PollResultsWriter) error
+	GetPollTextAnswers(user *model.User, pollID string) ([]model.PollTextAnswer, error)
+	ModeratePollTextAnswer(user *model.User, pollID string, answerID string, moderation model.PollTextModeration) error
 	StartPoll(user *model.User, pollID string) error
-	EndPoll(user *model.User, pollID string) error
+	EndPoll(user *model.User, pollID string, admin bool) error
+	PausePoll(user *model.User, pollID string) error
+	ReopenPoll(user *model.User, pollID string) error
 
 	SubscribeToPoll(user *model.User, pollID string, resultChan chan map[string]interface{}) error
 
 	//CRUD Surveys
+	GetSurveys(user *model.User, filter model.SurveysFilter) ([]model.Survey, error)
 	GetSurvey(user *model.User, id string) (*model.Survey, error)
 	CreateSurvey(user *model.User, survey model.Survey, admin bool) (*model.Survey, error)
 	UpdateSurvey(user *model.User, survey model.Survey, id string, admin bool) error
@@ -48,6 +63,7 @@ type Services interface {
 	//CRUD Survey Response
 	GetSurveyResponse(user *model.User, id string) (*model.SurveyResponse, error)
 	GetSurveyResponses(user *model.User, surveyIDs []string, surveyTypes []string, startDate *time.Time, endDate *time.Time, limit *int, offset *int) ([]model.SurveyResponse, error)
+	GetSurveyResponsesPage(user *model.User, surveyIDs []string, surveyTypes []string, startDate *time.Time, endDate *time.Time, limit int, cursor string) ([]model.SurveyResponse, *string, error)
 	CreateSurveyResponse(user *model.User, survey model.Survey) (*model.SurveyResponse, error)
 	UpdateSurveyResponse(user *model.User, id string, survey model.Survey) error
 	DeleteSurveyResponse(user *model.User, id string) error
@@ -61,6 +77,34 @@ type Services interface {
 	DeleteAlertContact(user *model.User, id string) error
 	CreateSurveyAlert(user *model.User, surveyAlert model.SurveyAlert) error
 
+	//CRUD Stadiums
+	GetStadiums(user *model.User) ([]model.Stadium, error)
+	GetStadium(user *model.User, id string) (*model.Stadium, error)
+	CreateStadium(user *model.User, stadium model.Stadium) (*model.Stadium, error)
+	UpdateStadium(user *model.User, id string, stadium model.Stadium) error
+	DeleteStadium(user *model.User, id string) error
+
+	//CRUD Poll Templates
+	GetPollTemplates(user *model.User) ([]model.PollTemplate, error)
+	GetPollTemplate(user *model.User, id string) (*model.PollTemplate, error)
+	CreatePollTemplate(user *model.User, template model.PollTemplate) (*model.PollTemplate, error)
+	UpdatePollTemplate(user *model.User, id string, template model.PollTemplate) (*model.PollTemplate, error)
+	DeletePollTemplate(user *model.User, id string) error
+
+	GetQuizLeaderboard(user *model.User, groupID *string, sessionID string, limit int) (*model.QuizLeaderboard, error)
+
+	//Poll Sessions
+	GetPollSessions(user *model.User) ([]model.PollSession, error)
+	GetPollSession(user *model.User, id string) (*model.PollSession, error)
+	GetPollSessionByPin(user *model.User, pin int) (*model.PollSession, error)
+	CreatePollSession(user *model.User, session model.PollSession) (*model.PollSession, error)
+	UpdatePollSession(user *model.User, id string, session model.PollSession) (*model.PollSession, error)
+	DeletePollSession(user *model.User, id string) error
+	AdvancePollSession(user *model.User, id string) (*model.PollSession, error)
+	EndPollSession(user *model.User, id string) (*model.PollSession, error)
+	SubscribeToPollSession(user *model.User, session *model.PollSession, resultChan chan map[string]interface{}) error
+	UnsubscribeFromPollSession(user *model.User, session *model.PollSession, resultChan chan map[string]interface{})
+
 	GetUserData(user *model.User) (*model.UserDataResponse, error)
 }
 
@@ -76,20 +120,28 @@ func (s *servicesImpl) GetPolls(user *model.User, filter model.PollsFilter, filt
 	return s.app.getPolls(user, filter, filterByToMembers)
 }
 
+func (s *servicesImpl) GetPollsPage(user *model.User, filter model.PollsFilter, filterByToMembers bool) ([]model.Poll, *string, error) {
+	return s.app.getPollsPage(user, filter, filterByToMembers)
+}
+
 func (s *servicesImpl) GetPoll(user *model.User, id string) (*model.Poll, error) {
 	return s.app.getPoll(user, id)
 }
 
+func (s *servicesImpl) GetPollByPin(user *model.User, pin int) (*model.Poll, error) {
+	return s.app.getPollByPin(user, pin)
+}
+
 func (s *servicesImpl) CreatePoll(user *model.User, poll model.Poll) (*model.Poll, error) {
 	return s.app.createPoll(user, poll)
 }
 
-func (s *servicesImpl) UpdatePoll(user *model.User, poll model.Poll) (*model.Poll, error) {
-	return s.app.updatePoll(user, poll)
+func (s *servicesImpl) UpdatePoll(user *model.User, poll model.Poll, admin bool) (*model.Poll, error) {
+	return s.app.updatePoll(user, poll, admin)
 }
 
-func (s *servicesImpl) DeletePoll(user *model.User, id string) error {
-	return s.app.deletePoll(user, id)
+func (s *servicesImpl) DeletePoll(user *model.User, id string, admin bool) error {
+	return s.app.deletePoll(user, id, admin)
 }
 
 func (s *servicesImpl) DeletePollsWithGroupID(user *model.User, groupID string) error {
@@ -100,18 +152,62 @@ func (s *servicesImpl) StartPoll(user *model.User, pollID string) error {
 	return s.app.startPoll(user, pollID)
 }
 
-func (s *servicesImpl) EndPoll(user *model.User, pollID string) error {
-	return s.app.endPoll(user, pollID)
+func (s *servicesImpl) EndPoll(user *model.User, pollID string, admin bool) error {
+	return s.app.endPoll(user, pollID, admin)
+}
+
+func (s *servicesImpl) PausePoll(user *model.User, pollID string) error {
+	return s.app.pausePoll(user, pollID)
+}
+
+func (s *servicesImpl) ReopenPoll(user *model.User, pollID string) error {
+	return s.app.reopenPoll(user, pollID)
+}
+
+func (s *servicesImpl) ClonePoll(user *model.User, pollID string, request model.PollCloneRequest) (*model.Poll, error) {
+	return s.app.clonePoll(user, pollID, request)
+}
+
+func (s *servicesImpl) GetPollSeries(user *model.User, id string) (*model.PollSeries, error) {
+	return s.app.getPollSeries(user, id)
+}
+
+func (s *servicesImpl) AddPollCoOwner(user *model.User, pollID string, userID string) (*model.Poll, error) {
+	return s.app.addPollCoOwner(user, pollID, userID)
+}
+
+func (s *servicesImpl) RemovePollCoOwner(user *model.User, pollID string, userID string) (*model.Poll, error) {
+	return s.app.removePollCoOwner(user, pollID, userID)
 }
 
 func (s *servicesImpl) VotePoll(user *model.User, pollID string, vote model.PollVote) error {
 	return s.app.votePoll(user, pollID, vote)
 }
 
+func (s *servicesImpl) RetractPollVote(user *model.User, pollID string) error {
+	return s.app.retractPollVote(user, pollID)
+}
+
+func (s *servicesImpl) ExportPollResults(user *model.User, pollID string, writer PollResultsWriter) error {
+	return s.app.exportPollResults(user, pollID, writer)
+}
+
+func (s *servicesImpl) GetPollTextAnswers(user *model.User, pollID string) ([]model.PollTextAnswer, error) {
+	return s.app.getPollTextAnswers(user, pollID)
+}
+
+func (s *servicesImpl) ModeratePollTextAnswer(user *model.User, pollID string, answerID string, moderation model.PollTextModeration) error {
+	return s.app.moderatePollTextAnswer(user, pollID, answerID, moderation)
+}
+
 func (s *servicesImpl) SubscribeToPoll(user *model.User, pollID string, resultChan chan map[string]interface{}) error {
 	return s.app.subscribeToPoll(user, pollID, resultChan)
 }
 
+func (s *servicesImpl) GetSurveys(user *model.User, filter model.SurveysFilter) ([]model.Survey, error) {
+	return s.app.getSurveys(user, filter)
+}
+
 func (s *servicesImpl) GetSurvey(user *model.User, id string) (*model.Survey, error) {
 	return s.app.getSurvey(user, id)
 }
@@ -140,6 +236,10 @@ func (s *servicesImpl) GetSurveyResponses(user *model.User, surveyIDs []string,
 	return s.app.getSurveyResponses(user, surveyIDs, surveyTypes, startDate, endDate, limit, offset)
 }
 
+func (s *servicesImpl) GetSurveyResponsesPage(user *model.User, surveyIDs []string, surveyTypes []string, startDate *time.Time, endDate *time.Time, limit int, cursor string) ([]model.SurveyResponse, *string, error) {
+	return s.app.getSurveyResponsesPage(user, surveyIDs, surveyTypes, startDate, endDate, limit, cursor)
+}
+
 func (s *servicesImpl) CreateSurveyResponse(user *model.User, survey model.Survey) (*model.SurveyResponse, error) {
 	return s.app.createSurveyResponse(user, survey)
 }
@@ -176,26 +276,137 @@ func (s *servicesImpl) CreateSurveyAlert(user *model.User, surveyAlert model.Sur
 	return s.app.createSurveyAlert(user, surveyAlert)
 }
 
+func (s *servicesImpl) GetStadiums(user *model.User) ([]model.Stadium, error) {
+	return s.app.getStadiums(user)
+}
+
+func (s *servicesImpl) GetStadium(user *model.User, id string) (*model.Stadium, error) {
+	return s.app.getStadium(user, id)
+}
+
+func (s *servicesImpl) CreateStadium(user *model.User, stadium model.Stadium) (*model.Stadium, error) {
+	return s.app.createStadium(user, stadium)
+}
+
+func (s *servicesImpl) UpdateStadium(user *model.User, id string, stadium model.Stadium) error {
+	return s.app.updateStadium(user, id, stadium)
+}
+
+func (s *servicesImpl) DeleteStadium(user *model.User, id string) error {
+	return s.app.deleteStadium(user, id)
+}
+
+func (s *servicesImpl) GetPollTemplates(user *model.User) ([]model.PollTemplate, error) {
+	return s.app.getPollTemplates(user)
+}
+
+func (s *servicesImpl) GetPollTemplate(user *model.User, id string) (*model.PollTemplate, error) {
+	return s.app.getPollTemplate(user, id)
+}
+
+func (s *servicesImpl) CreatePollTemplate(user *model.User, template model.PollTemplate) (*model.PollTemplate, error) {
+	return s.app.createPollTemplate(user, template)
+}
+
+func (s *servicesImpl) UpdatePollTemplate(user *model.User, id string, template model.PollTemplate) (*model.PollTemplate, error) {
+	return s.app.updatePollTemplate(user, id, template)
+}
+
+func (s *servicesImpl) DeletePollTemplate(user *model.User, id string) error {
+	return s.app.deletePollTemplate(user, id)
+}
+
+func (s *servicesImpl) GetQuizLeaderboard(user *model.User, groupID *string, sessionID string, limit int) (*model.QuizLeaderboard, error) {
+	return s.app.getQuizLeaderboard(user, groupID, sessionID, limit)
+}
+
+func (s *servicesImpl) GetPollSessions(user *model.User) ([]model.PollSession, error) {
+	return s.app.getPollSessions(user)
+}
+
+func (s *servicesImpl) GetPollSession(user *model.User, id string) (*model.PollSession, error) {
+	return s.app.getPollSession(user, id)
+}
+
+func (s *servicesImpl) GetPollSessionByPin(user *model.User, pin int) (*model.PollSession, error) {
+	return s.app.getPollSessionByPin(user, pin)
+}
+
+func (s *servicesImpl) CreatePollSession(user *model.User, session model.PollSession) (*model.PollSession, error) {
+	return s.app.createPollSession(user, session)
+}
+
+func (s *servicesImpl) UpdatePollSession(user *model.User, id string, session model.PollSession) (*model.PollSession, error) {
+	return s.app.updatePollSession(user, id, session)
+}
+
+func (s *servicesImpl) DeletePollSession(user *model.User, id string) error {
+	return s.app.deletePollSession(user, id)
+}
+
+func (s *servicesImpl) AdvancePollSession(user *model.User, id string) (*model.PollSession, error) {
+	return s.app.advancePollSession(user, id)
+}
+
+func (s *servicesImpl) EndPollSession(user *model.User, id string) (*model.PollSession, error) {
+	return s.app.endPollSession(user, id)
+}
+
+func (s *servicesImpl) SubscribeToPollSession(user *model.User, session *model.PollSession, resultChan chan map[string]interface{}) error {
+	return s.app.subscribeToPollSession(user, session, resultChan)
+}
+
+func (s *servicesImpl) UnsubscribeFromPollSession(user *model.User, session *model.PollSession, resultChan chan map[string]interface{}) {
+	s.app.unsubscribeFromPollSession(user, session, resultChan)
+}
+
 func (s *servicesImpl) GetUserData(user *model.User) (*model.UserDataResponse, error) {
 	return s.app.getUserData(user)
 }
 
+// PollResultsWriter writes the exported results of a poll in a specific format
+type PollResultsWriter interface {
+	WriteResult(result model.PollResult) error
+	WriteVote(vote model.PollExportVote) error
+}
+
 // Storage is used by core to storage data - DB storage adapter, file storage adapter etc
 type Storage interface {
 	GetPolls(user *model.User, filter model.PollsFilter, filterByToMembers bool, membership *groups.GroupMembership) ([]model.Poll, error)
 	GetPoll(user *model.User, id string, filterByToMembers bool, membership *groups.GroupMembership) (*model.Poll, error)
+	GetPollByPin(user *model.User, pin int, membership *groups.GroupMembership) (*model.Poll, error)
 	GetAllPolls() ([]model.Poll, error)
 	CreatePoll(user *model.User, poll model.Poll) (*model.Poll, error)
 	UpdatePoll(user *model.User, poll model.Poll) (*model.Poll, error)
+	AddPollCoOwner(orgID string, pollID primitive.ObjectID, userID string) error
+	RemovePollCoOwner(orgID string, pollID primitive.ObjectID, userID string) error
+
+	GetScheduledPollsToStart(now time.Time) ([]model.Poll, error)
+	GetScheduledPollsToEnd(now time.Time) ([]model.Poll, error)
+	GetNextPollScheduleTime() (*time.Time, error)
+	GetRecurringPollsDue(now time.Time) ([]model.Poll, error)
+	CreatePollInstance(series model.Poll, instance model.Poll, nextInstanceAt *time.Time, instancesCount int) (*model.Poll, error)
+	GetPollSeries(user *model.User, seriesID string, membership *groups.GroupMembership) ([]model.Poll, error)
+	UpdatePollStatus(poll model.Poll, transition model.PollStatusTransition) (bool, error)
 
 	DeletePoll(user *model.User, id string) error
 
-	VotePoll(user *model.User, pollID string, vote model.PollVote) error
+	VotePoll(user *model.User, pollID string, vote model.PollVote) (*model.Poll, error)
+	RetractPollVote(user *model.User, pollID string) error
+	GetPollVotes(orgID string, pollID string) ([]model.PollUserVotes, error)
+	GetUserPollVotes(user *model.User, pollIDs []primitive.ObjectID) ([]model.PollUserVotes, error)
+	GetPollVoterIDs(orgID string, pollID string, userIDs []string) ([]string, error)
+	ExportPollVotes(orgID string, pollID string, handler func(userVotes model.PollUserVotes) error) error
+	UpdatePollTally(orgID string, pollID string) error
+	GetPollsWithStaleTally() ([]model.Poll, error)
+	GetPollTextAnswers(orgID string, pollID string, withUserIDs bool) ([]model.PollTextAnswer, error)
+	ModeratePollTextAnswer(orgID string, pollID string, answerID string, moderation model.PollTextModeration) error
 	DeletePollsWithAccountIDs(orgID string, accountsIDs []string) error
 	DeletePollsWithGroupID(orgID *string, groupID string) ([]string, error)
 
 	SetListener(listener storage.CollectionListener)
 
+	GetSurveys(user *model.User, filter model.SurveysFilter) ([]model.Survey, error)
 	GetSurvey(user *model.User, id string) (*model.Survey, error)
 	GetSurveysByUserID(user *model.User) ([]model.Survey, error)
 	CreateSurvey(survey model.Survey) (*model.Survey, error)
@@ -204,7 +415,7 @@ type Storage interface {
 	DeleteSurveysWithIDs(appID string, orgID string, accountsIDs []string) error
 
 	GetSurveyResponse(user *model.User, id string) (*model.SurveyResponse, error)
-	GetSurveyResponses(user *model.User, surveyIDs []string, surveyTypes []string, startDate *time.Time, endDate *time.Time, limit *int, offset *int) ([]model.SurveyResponse, error)
+	GetSurveyResponses(user *model.User, surveyIDs []string, surveyTypes []string, startDate *time.Time, endDate *time.Time, limit *int, offset *int, cursor *string) ([]model.SurveyResponse, error)
 	GetSurveyResponseByUserID(user *model.User) ([]model.SurveyResponse, error)
 	CreateSurveyResponse(surveyResponse model.SurveyResponse) (*model.SurveyResponse, error)
 	UpdateSurveyResponse(user *model.User, id string, surveyResponse model.Survey) error
@@ -218,6 +429,33 @@ type Storage interface {
 	UpdateAlertContact(user *model.User, id string, alertContact model.AlertContact) error
 	DeleteAlertContact(user *model.User, id string) error
 	GetAlertContactsByKey(key string, user *model.User) ([]model.AlertContact, error)
+
+	GetStadiums(orgID string) ([]model.Stadium, error)
+	GetStadium(orgID string, id string) (*model.Stadium, error)
+	GetStadiumByKey(orgID string, key string) (*model.Stadium, error)
+	CreateStadium(stadium model.Stadium) (*model.Stadium, error)
+	UpdateStadium(orgID string, id string, stadium model.Stadium) error
+	DeleteStadium(orgID string, id string) error
+
+	GetPollTemplates(orgID string, userID string, groupIDs []string) ([]model.PollTemplate, error)
+	GetPollTemplate(orgID string, id string) (*model.PollTemplate, error)
+	CreatePollTemplate(template model.PollTemplate) (*model.PollTemplate, error)
+	UpdatePollTemplate(orgID string, id string, template model.PollTemplate) error
+	DeletePollTemplate(orgID string, id string) error
+	DeletePollTemplatesWithAccountIDs(orgID string, accountsIDs []string) error
+
+	ReplaceQuizScores(orgID string, pollID string, scores []model.QuizScore) error
+	GetQuizLeaderboard(orgID string, groupID *string, sessionID string, limit int) ([]model.QuizLeaderboardEntry, error)
+	DeleteQuizScoresWithAccountIDs(orgID string, accountsIDs []string) error
+
+	GetPollSessions(orgID string, userID string) ([]model.PollSession, error)
+	GetPollSession(orgID string, id string) (*model.PollSession, error)
+	GetPollSessionByPin(orgID string, pin int) (*model.PollSession, error)
+	CreatePollSession(session model.PollSession) (*model.PollSession, error)
+	UpdatePollSession(session model.PollSession) (*model.PollSession, error)
+	UpdatePollSessionStatus(session model.PollSession, status string, currentPollID string) (bool, error)
+	DeletePollSession(orgID string, id string) error
+	DeletePollSessionsWithAccountIDs(orgID string, accountsIDs []string) error
 }
 
 // Core exposes Core APIs for the driver adapters
@@ -0,0 +1,77 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultPageLimit the number of items of a page when the limit is not set
+const DefaultPageLimit = 20
+
+// ErrInvalidCursor the pagination cursor is not valid
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageCursor represents the sort keys of the last item of a page. The next page starts after this item.
+// The keys never change, so an item is neither skipped nor repeated when the items change between the pages.
+// The clients get it as an opaque token.
+type PageCursor struct {
+	ID   string     `json:"id"`
+	Date *time.Time `json:"date,omitempty"`
+}
+
+// Encode encodes the cursor as an opaque token
+func (c *PageCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageCursor decodes a cursor token. An empty token means the first page, so nil is returned.
+func DecodePageCursor(token string) (*PageCursor, error) {
+	if len(token) == 0 {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCursor, err)
+	}
+
+	var cursor PageCursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCursor, err)
+	}
+	if len(cursor.ID) == 0 {
+		return nil, fmt.Errorf("%w: missing id", ErrInvalidCursor)
+	}
+	return &cursor, nil
+}
+
+// PollsPage represents a page of polls
+type PollsPage struct {
+	Items      []PollResult `json:"items"`
+	NextCursor *string      `json:"next_cursor"` // nil on the last page
+} // @name PollsPage
+
+// SurveyResponsesPage represents a page of survey responses
+type SurveyResponsesPage struct {
+	Items      []SurveyResponse `json:"items"`
+	NextCursor *string          `json:"next_cursor"` // nil on the last page
+} // @name SurveyResponsesPage
@@ -0,0 +1,77 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPageCursorEncodeDecode(t *testing.T) {
+	date := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		cursor PageCursor
+	}{
+		{"id", PageCursor{ID: "64b7f0c2a1e3d4f5a6b7c8d9"}},
+		{"id and date", PageCursor{ID: "64b7f0c2a1e3d4f5a6b7c8d9", Date: &date}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodePageCursor(tt.cursor.Encode())
+			if err != nil {
+				t.Fatalf("DecodePageCursor() error = %v", err)
+			}
+			if got.ID != tt.cursor.ID {
+				t.Errorf("DecodePageCursor() id = %s, want %s", got.ID, tt.cursor.ID)
+			}
+			if (got.Date == nil) != (tt.cursor.Date == nil) || (got.Date != nil && !got.Date.Equal(*tt.cursor.Date)) {
+				t.Errorf("DecodePageCursor() date = %v, want %v", got.Date, tt.cursor.Date)
+			}
+		})
+	}
+}
+
+func TestDecodePageCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		wantNil bool
+		wantErr bool
+	}{
+		{"first page", "", true, false},
+		{"not base64", "not a cursor!", true, true},
+		{"not json", base64.RawURLEncoding.EncodeToString([]byte("id=1")), true, true},
+		{"missing id", base64.RawURLEncoding.EncodeToString([]byte(`{"date":"2026-03-02T09:30:00Z"}`)), true, true},
+		{"legacy status key", base64.RawURLEncoding.EncodeToString([]byte(`{"id":"64b7f0c2a1e3d4f5a6b7c8d9","status":"started"}`)), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodePageCursor(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodePageCursor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("DecodePageCursor() error = %v, want %v", err, ErrInvalidCursor)
+			}
+			if (got == nil) != tt.wantNil {
+				t.Errorf("DecodePageCursor() = %v, wantNil %v", got, tt.wantNil)
+			}
+		})
+	}
+}
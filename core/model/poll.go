@@ -15,6 +15,11 @@
 package model
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -24,38 +29,215 @@ import (
 type PollsFilter struct {
 	Pin     *int     `json:"pin"`
 	PollIDs []string `json:"poll_ids,omitempty"`
-	MyPolls *bool    `json:"my_polls,omitempty"`
+	MyPolls *bool    `json:"my_polls,omitempty"` // the polls the user has created or co-owns
 	//GroupPolls     *bool    `json:"group_polls,omitempty"`
 	GroupIDs       []string `json:"group_ids,omitempty"`
 	RespondedPolls *bool    `json:"responded_polls,omitempty"`
 	Statuses       []string `json:"statuses,omitempty"`
+	UserIDs        []string `json:"user_ids,omitempty"` // the creators of the polls
+	Stadiums       []string `json:"stadiums,omitempty"`
+	Search         string   `json:"search,omitempty"` // full-text search over the question and the options, the most relevant polls first
 	Offset         *int64   `json:"offset,omitempty"`
 	Limit          *int64   `json:"limit,omitempty"`
+	Cursor         *string  `json:"cursor,omitempty"` // the next_cursor of the previous page, empty for the first page. The offset is ignored.
 } // @name PollsFilter
 
 // PollData data stored for a poll
 type PollData struct {
-	UserID        string    `json:"userid" bson:"userid" validate:"required"`
-	UserName      string    `json:"username" bson:"username" validate:"required"`
-	ToMembersList ToMembers `json:"to_members" bson:"to_members"` // nil or empty means everyone; non-empty means visible to those user ids
-	Question      string    `json:"question" bson:"question" validate:"required"`
-	Options       []string  `json:"options" bson:"options" validate:"required,min=2,dive,required"`
-	GroupID       *string   `json:"group_id,omitempty" bson:"group_id"`
-	Pin           int       `json:"pin,omitempty" bson:"pin" validate:"min=0,max=9999"`
-	MultiChoice   bool      `json:"multi_choice" bson:"multi_choice"`
-	Repeat        bool      `json:"repeat" bson:"repeat"`
-	ShowResults   bool      `json:"show_results" bson:"show_results"`
-	Stadium       string    `json:"stadium" bson:"stadium"`
-	Geo           bool      `json:"geo_fence" bson:"geo_fence"`
-	Status        string    `json:"status" bson:"status" validate:"required,oneof=created started"`
-	DateCreated   time.Time `json:"date_created" bson:"date_created"`
-	DateUpdated   time.Time `json:"date_updated" bson:"date_updated"`
+	UserID            string                      `json:"userid" bson:"userid" validate:"required"`
+	UserName          string                      `json:"username" bson:"username" validate:"required"`
+	ToMembersList     ToMembers                   `json:"to_members" bson:"to_members"` // nil or empty means everyone; non-empty means visible to those user ids
+	Question          string                      `json:"question" bson:"question" validate:"required"`
+	Options           []string                    `json:"options" bson:"options" validate:"required,min=2,dive,required"`
+	GroupID           *string                     `json:"group_id,omitempty" bson:"group_id"`
+	Pin               int                         `json:"pin,omitempty" bson:"pin" validate:"min=0,max=9999"`
+	AutoPin           bool                        `json:"auto_pin,omitempty" bson:"-"` // the server allocates a PIN which is not used by another active poll
+	MultiChoice       bool                        `json:"multi_choice" bson:"multi_choice"`
+	Repeat            bool                        `json:"repeat" bson:"repeat"`                       // the same user can vote more than once, the polls recurring on schedule use the recurrence
+	AllowVoteChange   bool                        `json:"allow_vote_change" bson:"allow_vote_change"` // the voters can replace or retract their vote while the poll is started
+	ShowResults       bool                        `json:"show_results" bson:"show_results"`
+	ResultsVisibility string                      `json:"results_visibility,omitempty" bson:"results_visibility,omitempty"` // always, after_vote or after_end, defaults from show_results
+	Stadium           string                      `json:"stadium" bson:"stadium"`
+	Geo               bool                        `json:"geo_fence" bson:"geo_fence"`
+	GeoRegion         *PollGeoRegion              `json:"geo_region,omitempty" bson:"geo_region,omitempty"` // the region where a geo-fenced poll can be voted
+	Anonymous         bool                        `json:"anonymous" bson:"anonymous"`                       // the voter identities are never returned, only the aggregated results
+	Status            string                      `json:"status" bson:"status" validate:"required,oneof=created started paused terminated"`
+	PollType          string                      `json:"poll_type,omitempty" bson:"poll_type,omitempty"`           // choice (default), ranked, rating, numeric or open_text
+	Scale             *PollScale                  `json:"scale,omitempty" bson:"scale,omitempty"`                   // the allowed values of the rating and numeric polls
+	StartAt           *time.Time                  `json:"start_at,omitempty" bson:"start_at,omitempty"`             // the poll is started automatically at this time if it is still created
+	EndAt             *time.Time                  `json:"end_at,omitempty" bson:"end_at,omitempty"`                 // the poll is ended automatically at this time if it is not terminated
+	AutoClose         *PollAutoClose              `json:"auto_close,omitempty" bson:"auto_close,omitempty"`         // the poll is ended automatically when the voters or option votes limit is reached
+	TemplateID        string                      `json:"template_id,omitempty" bson:"template_id,omitempty"`       // the template the poll is created from
+	Quiz              *PollQuiz                   `json:"quiz,omitempty" bson:"quiz,omitempty"`                     // the correct answer of a quiz poll
+	SessionID         string                      `json:"session_id,omitempty" bson:"session_id,omitempty"`         // the session the poll is presented in, set by the session. The quiz scores are kept for the session, besides the group.
+	DefaultLocale     string                      `json:"default_locale,omitempty" bson:"default_locale,omitempty"` // the locale of the question and the options, e.g. en
+	Localizations     map[string]PollLocalization `json:"localizations,omitempty" bson:"localizations,omitempty"`   // the localized question and options keyed by locale
+	Recurrence        *PollRecurrence             `json:"recurrence,omitempty" bson:"recurrence,omitempty"`         // the rule which creates the next instances of the poll on schedule
+	SeriesID          string                      `json:"series_id,omitempty" bson:"series_id,omitempty"`           // the recurring poll the instance is created from, set by the server
+	CoOwners          []string                    `json:"co_owners,omitempty" bson:"co_owners,omitempty"`           // the users who manage the poll with the same rights as the creator
+	DateCreated       time.Time                   `json:"date_created" bson:"date_created"`
+	DateUpdated       time.Time                   `json:"date_updated" bson:"date_updated"`
 } // @name PollData
 
+// MaxPollPin the max PIN of a poll. PIN 0 means the poll has no PIN.
+const MaxPollPin = 9999
+
+const (
+	// PollTypeChoice the answer is a set of selected options. It is the default type.
+	PollTypeChoice = "choice"
+	// PollTypeRanked the answer is a ranking of the options, most preferred first. The results are tallied with instant-runoff.
+	PollTypeRanked = "ranked"
+	// PollTypeRating the answer is a value of a rating scale, e.g. stars or Likert. The options may label the scale values.
+	PollTypeRating = "rating"
+	// PollTypeNumeric the answer is a number within the scale
+	PollTypeNumeric = "numeric"
+	// PollTypeOpenText the answer is a short text. The answers appear in the results once approved by a moderator.
+	PollTypeOpenText = "open_text"
+)
+
+const (
+	// ResultsVisibilityAlways the results are visible to everyone
+	ResultsVisibilityAlways = "always"
+	// ResultsVisibilityAfterVote the results are visible to the users who have voted, and to everyone once the poll ends
+	ResultsVisibilityAfterVote = "after_vote"
+	// ResultsVisibilityAfterEnd the results are visible to everyone once the poll ends
+	ResultsVisibilityAfterEnd = "after_end"
+)
+
+// Validate checks if the poll type settings and the scheduled start and end times of the poll are consistent
+func (pd *PollData) Validate() error {
+	if pd.Pin < 0 || pd.Pin > MaxPollPin {
+		return fmt.Errorf("%w: pin must be between 0 and %d", ErrInvalidPoll, MaxPollPin)
+	}
+
+	switch pd.PollType {
+	case "", PollTypeChoice, PollTypeRanked, PollTypeOpenText:
+	case PollTypeRating, PollTypeNumeric:
+		if pd.Scale == nil {
+			return fmt.Errorf("%w: scale is required for %s polls", ErrInvalidPoll, pd.PollType)
+		}
+		err := pd.Scale.Validate()
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: unknown poll type %s", ErrInvalidPoll, pd.PollType)
+	}
+
+	switch pd.ResultsVisibility {
+	case "", ResultsVisibilityAlways, ResultsVisibilityAfterVote, ResultsVisibilityAfterEnd:
+	default:
+		return fmt.Errorf("%w: unknown results visibility %s", ErrInvalidPoll, pd.ResultsVisibility)
+	}
+
+	if pd.StartAt != nil && pd.EndAt != nil && !pd.EndAt.After(*pd.StartAt) {
+		return fmt.Errorf("%w: end_at must be after start_at", ErrInvalidPoll)
+	}
+
+	if pd.AutoClose != nil {
+		err := pd.AutoClose.Validate(pd)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pd.Quiz != nil {
+		err := pd.Quiz.Validate(pd)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pd.Recurrence != nil {
+		err := pd.Recurrence.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	err := pd.validateLocalizations()
+	if err != nil {
+		return err
+	}
+
+	if pd.GeoRegion != nil {
+		return pd.GeoRegion.Validate()
+	}
+	return nil
+}
+
+// IsRanked checks if the answers of the poll are rankings
+func (pd *PollData) IsRanked() bool {
+	return pd.PollType == PollTypeRanked
+}
+
+// IsScale checks if the answers of the poll are scale values
+func (pd *PollData) IsScale() bool {
+	return pd.PollType == PollTypeRating || pd.PollType == PollTypeNumeric
+}
+
+// IsOpenText checks if the answers of the poll are texts
+func (pd *PollData) IsOpenText() bool {
+	return pd.PollType == PollTypeOpenText
+}
+
+// GetResultsVisibility gets the results visibility mode. The polls without a mode show the results
+// after voting if show_results is set, otherwise after the end.
+func (pd *PollData) GetResultsVisibility() string {
+	if len(pd.ResultsVisibility) > 0 {
+		return pd.ResultsVisibility
+	}
+	if pd.ShowResults {
+		return ResultsVisibilityAfterVote
+	}
+	return ResultsVisibilityAfterEnd
+}
+
+// ResultsVisibleTo checks if the user can see the results. The owners of the poll can always see them.
+func (pd *PollData) ResultsVisibleTo(userID string, voted bool) bool {
+	if pd.IsOwner(userID) || pd.Status == PollStatusTerminated {
+		return true
+	}
+
+	switch pd.GetResultsVisibility() {
+	case ResultsVisibilityAlways:
+		return true
+	case ResultsVisibilityAfterVote:
+		return voted
+	default:
+		return false
+	}
+}
+
+// HasSameAnswerFormat checks if the answers of both polls are interpreted the same way. The counters are kept per
+// option index, so the options of a choice or ranked poll must be the same, in the same order.
+func (pd *PollData) HasSameAnswerFormat(other PollData) bool {
+	if pd.PollType != other.PollType {
+		return false
+	}
+	if !pd.IsScale() && !pd.IsOpenText() {
+		return slices.Equal(pd.Options, other.Options)
+	}
+	if pd.Scale == nil || other.Scale == nil {
+		return pd.Scale == other.Scale
+	}
+	return *pd.Scale == *other.Scale
+}
+
+// IsOwner checks if the user is the creator or a co-owner of the poll
+func (pd *PollData) IsOwner(userID string) bool {
+	return pd.UserID == userID || pd.IsCoOwner(userID)
+}
+
+// IsCoOwner checks if the user is a co-owner of the poll
+func (pd *PollData) IsCoOwner(userID string) bool {
+	return len(userID) > 0 && slices.Contains(pd.CoOwners, userID)
+}
+
 // UserHasAccess Checks if the user has read and write access to the poll object
 func (pd *PollData) UserHasAccess(userID string) bool {
 
-	if pd.UserID == userID {
+	if pd.IsOwner(userID) {
 		return true
 	}
 
@@ -92,56 +274,105 @@ type ToMember struct {
 	ExternalID string `json:"external_id" bson:"external_id"`
 	Name       string `json:"name" bson:"name"`
 	Email      string `json:"email" bson:"email"`
+	Locale     string `json:"locale,omitempty" bson:"locale,omitempty"` // the preferred locale of the member for the notifications
 } //@name ToMember
 
 // PollNotification wraps the entire record
 type PollNotification struct {
-	PollData  `json:"poll" bson:"poll"`
-	OrgID     string             `json:"org_id" bson:"org_id"`
-	ID        primitive.ObjectID `json:"_id" bson:"_id"`
-	Responses []PollVote         `json:"responses" bson:"responses,omitempty" validate:"max=0"`
-	Results   []int              `json:"results" bson:"results,omitempty" validate:"max=0"`
+	PollData    `json:"poll" bson:"poll"`
+	OrgID       string             `json:"org_id" bson:"org_id"`
+	ID          primitive.ObjectID `json:"_id" bson:"_id"`
+	Responses   []PollVote         `json:"responses" bson:"responses,omitempty" validate:"max=0"`
+	Results     []int              `json:"results" bson:"results,omitempty" validate:"max=0"`
+	Counters    *PollCounters      `json:"counters,omitempty" bson:"counters,omitempty"`
+	Runoff      *RunoffResult      `json:"runoff,omitempty" bson:"runoff,omitempty"`
+	TextResults *PollTextResults   `json:"text_results,omitempty" bson:"text_results,omitempty"`
 } // @name PollNotification
 
-// ToPollResult converts to PollResult
-func (poll *PollNotification) ToPollResult(currentUserID string) PollResult {
-
-	result := PollResult{
-		PollData: poll.PollData,
-	}
-
-	votes := make(map[int]bool)
-	votersMap := make(map[string]bool)
+// ToPollResult converts to PollResult. The notification does not contain the user votes, so the caller tells if the user has voted.
+func (poll *PollNotification) ToPollResult(currentUserID string, voted bool) PollResult {
+	return toPollResult(poll.ID, poll.PollData, poll.Responses, poll.Results, poll.Counters, poll.Runoff, poll.TextResults, currentUserID, voted)
+}
 
-	result.ID = poll.ID
-	result.PollData = poll.PollData
+// Poll wraps the entire record
+type Poll struct {
+	PollData    `json:"poll" bson:"poll"`
+	OrgID       string                 `json:"org_id" bson:"org_id"`
+	ID          primitive.ObjectID     `json:"id" bson:"_id"`
+	Responses   []PollVote             `json:"responses" bson:"responses,omitempty" validate:"max=0"`
+	Results     []int                  `json:"results" bson:"results,omitempty" validate:"max=0"`
+	Counters    *PollCounters          `json:"counters,omitempty" bson:"counters,omitempty"`
+	Runoff      *RunoffResult          `json:"runoff,omitempty" bson:"runoff,omitempty"`             // the instant-runoff tally of a ranked poll
+	TextResults *PollTextResults       `json:"text_results,omitempty" bson:"text_results,omitempty"` // the approved answers of an open text poll
+	ActivePin   *int                   `json:"-" bson:"active_pin,omitempty"`                        // the PIN reserved while the poll is not terminated
+	Transitions []PollStatusTransition `json:"transitions,omitempty" bson:"transitions,omitempty"`   // the status changes, oldest first
+
+	NextInstanceAt *time.Time `json:"next_instance_at,omitempty" bson:"next_instance_at,omitempty"` // the time the next instance of a recurring poll is created
+	InstancesCount int        `json:"instances_count,omitempty" bson:"instances_count,omitempty"`   // the number of the polls of a recurring series created so far
+} // @name Poll
 
-	count := len(result.PollData.Options)
-	result.Results = make([]int, count)
+// ToPollResult converts to PollResult. The poll responses contain the current user votes.
+func (poll *Poll) ToPollResult(currentUserID string) PollResult {
+	voted := false
+	for _, e := range poll.Responses {
+		if e.UserID == currentUserID {
+			voted = true
+			break
+		}
+	}
+	result := toPollResult(poll.ID, poll.PollData, poll.Responses, poll.Results, poll.Counters, poll.Runoff, poll.TextResults, currentUserID, voted)
+	result.Transitions = poll.Transitions
+	return result
+}
 
-	if len(poll.Responses) > 0 {
-		for _, e := range poll.Responses {
-			votersMap[e.UserID] = true
+func toPollResult(id primitive.ObjectID, pollData PollData, responses []PollVote, results []int, counters *PollCounters, runoff *RunoffResult, textResults *PollTextResults, currentUserID string, voted bool) PollResult {
+	result := PollResult{
+		PollData: pollData,
+		ID:       id,
+	}
+	if pollData.IsRanked() {
+		result.Runoff = runoff
+	}
 
-			userVoted := poll.UserID == currentUserID
+	count := len(pollData.Options)
+	if counters == nil {
+		// not backfilled poll
+		calculated := CountPollVotes(responses, results)
+		counters = &calculated
+	}
 
-			for _, a := range e.Answer {
-				if a >= 0 && a < count {
-					if userVoted {
-						votes[a] = true
-					}
-					result.Results[a]++
-				}
-			}
+	if pollData.IsOpenText() {
+		// only the approved answers are counted
+		result.Results = []int{}
+		if textResults != nil {
+			result.TextResults = textResults
+			result.Total = textResults.Total
 		}
+	} else if pollData.IsScale() && pollData.Scale != nil {
+		// the counters are per scale step
+		stats := CalculateScaleStats(*pollData.Scale, counters.Options)
+		result.Stats = &stats
+		result.Results = []int{}
+		result.Total = stats.Count
 	} else {
-		copy(result.Results, poll.Results)
+		result.Results = make([]int, count)
+		for i := range result.Results {
+			result.Results[i] = counters.Options[strconv.Itoa(i)]
+			result.Total += result.Results[i]
+		}
 	}
+	result.UniqueVotersCount = counters.UniqueVoters
 
-	result.UniqueVotersCount = len(votersMap)
-
-	for _, n := range result.Results {
-		result.Total += n
+	votes := make(map[int]bool)
+	for _, e := range responses {
+		if e.UserID != currentUserID {
+			continue
+		}
+		for _, a := range e.Answer {
+			if a >= 0 && a < count {
+				votes[a] = true
+			}
+		}
 	}
 
 	if l := len(votes); l > 0 {
@@ -153,69 +384,69 @@ func (poll *PollNotification) ToPollResult(currentUserID string) PollResult {
 		}
 	}
 
-	return result
-}
-
-// Poll wraps the entire record
-type Poll struct {
-	PollData  `json:"poll" bson:"poll"`
-	OrgID     string             `json:"org_id" bson:"org_id"`
-	ID        primitive.ObjectID `json:"id" bson:"_id"`
-	Responses []PollVote         `json:"responses" bson:"responses,omitempty" validate:"max=0"`
-	Results   []int              `json:"results" bson:"results,omitempty" validate:"max=0"`
-} // @name Poll
-
-// ToPollResult converts to PollResult
-func (poll *Poll) ToPollResult(currentUserID string) PollResult {
-
-	result := PollResult{
-		PollData: poll.PollData,
+	if pollData.Quiz != nil {
+		// the correct options are revealed to the voters once the poll ends
+		ended := pollData.Status == PollStatusTerminated
+		result.Quiz = pollData.Quiz.copy(!ended && !pollData.IsOwner(currentUserID))
+		if ended {
+			var lastAnswer []int
+			for _, e := range responses {
+				if e.UserID == currentUserID {
+					lastAnswer = e.Answer
+				}
+			}
+			if lastAnswer != nil {
+				correct := pollData.Quiz.IsCorrect(lastAnswer)
+				result.QuizCorrect = &correct
+			}
+		}
 	}
 
-	votes := make(map[int]bool)
-	votersMap := make(map[string]bool)
-
-	result.ID = poll.ID
-	result.PollData = poll.PollData
-
-	count := len(result.PollData.Options)
-	result.Results = make([]int, count)
-
-	if len(poll.Responses) > 0 {
-		for _, e := range poll.Responses {
-			votersMap[e.UserID] = true
+	if !pollData.ResultsVisibleTo(currentUserID, voted) {
+		// the voters count is not a result
+		result.Results = []int{}
+		result.Total = 0
+		result.Runoff = nil
+		result.Stats = nil
+		result.TextResults = nil
+		result.ResultsHidden = true
+	}
 
-			userVoted := poll.UserID == currentUserID
+	return result
+}
 
+// PollCounters represents the vote counters of a poll. They are incremented on every vote,
+// so the results are not recalculated from the responses on every read.
+type PollCounters struct {
+	Options      map[string]int `json:"options" bson:"options"` // votes count per option index
+	UniqueVoters int            `json:"unique_voters" bson:"unique_voters"`
+	Revision     int            `json:"revision" bson:"revision"` // incremented on every vote
+} // @name PollCounters
+
+// CountPollVotes calculates the counters from the poll responses. The legacy results are used if there are no responses.
+func CountPollVotes(responses []PollVote, results []int) PollCounters {
+	counters := PollCounters{Options: map[string]int{}}
+
+	if len(responses) > 0 {
+		voters := make(map[string]bool)
+		for _, e := range responses {
+			voters[e.UserID] = true
 			for _, a := range e.Answer {
-				if a >= 0 && a < count {
-					if userVoted {
-						votes[a] = true
-					}
-					result.Results[a]++
+				if a >= 0 {
+					counters.Options[strconv.Itoa(a)]++
 				}
 			}
 		}
+		counters.UniqueVoters = len(voters)
 	} else {
-		copy(result.Results, poll.Results)
-	}
-
-	result.UniqueVotersCount = len(votersMap)
-
-	for _, n := range result.Results {
-		result.Total += n
-	}
-
-	if l := len(votes); l > 0 {
-		result.Voted = make([]int, l)
-		i := 0
-		for k := range votes {
-			result.Voted[i] = k
-			i++
+		for i, n := range results {
+			if n > 0 {
+				counters.Options[strconv.Itoa(i)] = n
+			}
 		}
 	}
 
-	return result
+	return counters
 }
 
 // GetPollNotificationRecipients gets poll to members as notification recipients
@@ -234,19 +465,131 @@ func (poll *Poll) GetPollNotificationRecipients(currentUserID string) []UserRef
 	return recipients
 }
 
+var (
+	// ErrInvalidPoll the poll data is not valid
+	ErrInvalidPoll = errors.New("invalid poll")
+	// ErrInvalidVote the vote does not match the poll options or the poll choice rules
+	ErrInvalidVote = errors.New("invalid vote")
+
+	// ErrPollNotFound the poll does not exist or the user can not see it
+	ErrPollNotFound = errors.New("poll not found")
+
+	// ErrPollChanged the poll has been changed in the meantime, so the update does not apply to it anymore
+	ErrPollChanged = errors.New("poll has been changed")
+
+	// ErrPollNotStarted the poll does not accept votes because it is not started
+	ErrPollNotStarted = errors.New("poll is not started")
+
+	// ErrAlreadyVoted the user has already voted and the poll does not allow repeated votes
+	ErrAlreadyVoted = errors.New("user has already voted")
+
+	// ErrVoteChangeNotAllowed the poll does not allow changing or retracting a vote
+	ErrVoteChangeNotAllowed = errors.New("vote change is not allowed")
+
+	// ErrVoteNotFound the user has not voted
+	ErrVoteNotFound = errors.New("vote not found")
+
+	// ErrPollPinInUse the PIN is used by another active poll of the organization
+	ErrPollPinInUse = errors.New("pin is used by another active poll")
+
+	// ErrPollPermission the user is not allowed to perform the operation on the poll
+	ErrPollPermission = errors.New("permission denied")
+
+	// ErrTextAnswerNotFound the text answer does not exist
+	ErrTextAnswerNotFound = errors.New("text answer not found")
+)
+
+// ValidateVote checks if the vote answer is valid for the poll options and choice rules
+func (pd *PollData) ValidateVote(vote PollVote) error {
+	if pd.IsOpenText() {
+		if len(vote.Answer) > 0 || vote.Value != nil {
+			return fmt.Errorf("%w: the poll accepts a text only", ErrInvalidVote)
+		}
+		if len(strings.TrimSpace(vote.Text)) == 0 {
+			return fmt.Errorf("%w: empty text", ErrInvalidVote)
+		}
+		if len([]rune(vote.Text)) > MaxTextAnswerLength {
+			return fmt.Errorf("%w: the text is longer than %d characters", ErrInvalidVote, MaxTextAnswerLength)
+		}
+		return nil
+	}
+	if len(vote.Text) > 0 {
+		return fmt.Errorf("%w: the poll does not accept a text", ErrInvalidVote)
+	}
+
+	if pd.IsScale() {
+		if vote.Value == nil {
+			return fmt.Errorf("%w: empty value", ErrInvalidVote)
+		}
+		if len(vote.Answer) > 0 {
+			return fmt.Errorf("%w: the poll accepts a value only", ErrInvalidVote)
+		}
+		if pd.Scale == nil {
+			return fmt.Errorf("%w: the poll has no scale", ErrInvalidVote)
+		}
+		if _, ok := pd.Scale.StepIndex(*vote.Value); !ok {
+			return fmt.Errorf("%w: value %v is not on the scale", ErrInvalidVote, *vote.Value)
+		}
+		return nil
+	}
+
+	if len(vote.Answer) == 0 {
+		return fmt.Errorf("%w: empty answer", ErrInvalidVote)
+	}
+	if !pd.MultiChoice && !pd.IsRanked() && len(vote.Answer) > 1 {
+		return fmt.Errorf("%w: the poll allows a single answer", ErrInvalidVote)
+	}
+
+	answered := map[int]bool{}
+	for _, a := range vote.Answer {
+		if a < 0 || a >= len(pd.Options) {
+			return fmt.Errorf("%w: option %d is out of range", ErrInvalidVote, a)
+		}
+		if answered[a] {
+			return fmt.Errorf("%w: option %d is answered more than once", ErrInvalidVote, a)
+		}
+		answered[a] = true
+	}
+	return nil
+}
+
 // PollVote data stored for each response
 type PollVote struct {
-	UserID  string    `json:"userid" validate:"required"`
-	Answer  []int     `json:"answer" validate:"required,min=1"`
-	Created time.Time `json:"created"`
+	UserID   string        `json:"userid" validate:"required"`
+	Answer   []int         `json:"answer" validate:"required,min=1"`
+	Value    *float64      `json:"value,omitempty"` // the answer of the rating and numeric polls
+	Text     string        `json:"text,omitempty"`  // the answer of the open text polls
+	ID       string        `json:"id,omitempty"`    // set for the open text answers only
+	Status   string        `json:"status,omitempty"`
+	Pinned   bool          `json:"pinned,omitempty"`
+	Location *VoteLocation `json:"location,omitempty" bson:"-"` // used for the geo fence check only, never stored
+	Created  time.Time     `json:"created"`
 } // @name PollVote
 
+// PollUserVotes wraps all votes of a user for a poll
+type PollUserVotes struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	OrgID       string             `json:"org_id" bson:"org_id"`
+	PollID      primitive.ObjectID `json:"poll_id" bson:"poll_id"`
+	UserID      string             `json:"user_id" bson:"user_id"`
+	Votes       []PollVote         `json:"votes" bson:"votes"`
+	DateCreated time.Time          `json:"date_created" bson:"date_created"`
+	DateUpdated time.Time          `json:"date_updated" bson:"date_updated"`
+} // @name PollUserVotes
+
 // PollResult wraps poll result
 type PollResult struct {
 	PollData          `json:"poll" bson:""`
-	ID                primitive.ObjectID `json:"id"`
-	Voted             []int              `json:"voted,omitempty"`
-	Results           []int              `json:"results"`
-	UniqueVotersCount int                `json:"unique_voters_count"`
-	Total             int                `json:"total"`
+	ID                primitive.ObjectID     `json:"id"`
+	Voted             []int                  `json:"voted,omitempty"`
+	Results           []int                  `json:"results"`
+	UniqueVotersCount int                    `json:"unique_voters_count"`
+	Total             int                    `json:"total"`
+	Runoff            *RunoffResult          `json:"runoff,omitempty"`         // the instant-runoff rounds and winner of a ranked poll
+	Stats             *PollStats             `json:"stats,omitempty"`          // the answers statistics of a rating or numeric poll
+	TextResults       *PollTextResults       `json:"text_results,omitempty"`   // the approved answers and word frequencies of an open text poll
+	ResultsHidden     bool                   `json:"results_hidden,omitempty"` // the results are not visible to the user yet
+	Transitions       []PollStatusTransition `json:"transitions,omitempty"`    // the status changes, oldest first
+	Locale            string                 `json:"locale,omitempty"`         // the locale of the returned question and options
+	QuizCorrect       *bool                  `json:"quiz_correct,omitempty"`   // whether the last answer of the user is correct, set once the quiz poll ends
 } // @name PollResult
@@ -0,0 +1,85 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrPollVoteLimitReached the poll does not accept the vote because it would go over an auto close limit
+var ErrPollVoteLimitReached = errors.New("poll vote limit reached")
+
+// PollAutoClose represents the conditions which end a started poll automatically. A zero value disables a condition.
+type PollAutoClose struct {
+	MaxVoters   int `json:"max_voters,omitempty" bson:"max_voters,omitempty"`     // the poll ends when this number of unique voters is reached
+	Option      int `json:"option" bson:"option"`                                 // the option index checked against option_votes
+	OptionVotes int `json:"option_votes,omitempty" bson:"option_votes,omitempty"` // the poll ends when the option reaches this number of votes
+} // @name PollAutoClose
+
+// Validate checks if the auto close conditions fit the poll
+func (ac *PollAutoClose) Validate(pd *PollData) error {
+	if ac.MaxVoters < 0 || ac.OptionVotes < 0 {
+		return fmt.Errorf("%w: auto close limits must not be negative", ErrInvalidPoll)
+	}
+	if ac.OptionVotes > 0 {
+		if pd.IsScale() || pd.IsOpenText() {
+			return fmt.Errorf("%w: auto close on option votes is not supported for %s polls", ErrInvalidPoll, pd.PollType)
+		}
+		if ac.Option < 0 || ac.Option >= len(pd.Options) {
+			return fmt.Errorf("%w: auto close option %d is not a poll option", ErrInvalidPoll, ac.Option)
+		}
+	}
+	return nil
+}
+
+// OptionCounterKey gets the key of the checked option in the poll counters
+func (ac *PollAutoClose) OptionCounterKey() string {
+	return strconv.Itoa(ac.Option)
+}
+
+// Reason gets the reason for ending the poll if one of the conditions is reached by the counters, otherwise an empty string
+func (ac *PollAutoClose) Reason(counters PollCounters) string {
+	if ac.MaxVoters > 0 && counters.UniqueVoters >= ac.MaxVoters {
+		return PollTransitionReasonMaxVoters
+	}
+	if ac.OptionVotes > 0 && counters.Options[ac.OptionCounterKey()] >= ac.OptionVotes {
+		return PollTransitionReasonOptionVotes
+	}
+	return ""
+}
+
+// AcceptsVote checks if the counters accept a vote. The voters limit applies to a new voter only and the option votes
+// limit applies to a vote which adds to the checked option only, so the other votes are accepted until the poll is ended.
+func (ac *PollAutoClose) AcceptsVote(counters PollCounters, newVoter bool, optionDelta int) bool {
+	if ac.MaxVoters > 0 && newVoter && counters.UniqueVoters >= ac.MaxVoters {
+		return false
+	}
+	if ac.OptionVotes > 0 && optionDelta > 0 && counters.Options[ac.OptionCounterKey()] >= ac.OptionVotes {
+		return false
+	}
+	return true
+}
+
+// ValidateStart checks if the poll can be started with the counters. A poll which has reached a limit would not accept
+// any vote, the limit must be raised before the poll is started again.
+func (ac *PollAutoClose) ValidateStart(counters PollCounters) error {
+	reason := ac.Reason(counters)
+	if len(reason) > 0 {
+		return fmt.Errorf("%w: the %s auto close limit is reached, raise it before starting the poll", ErrInvalidPollTransition, reason)
+	}
+	return nil
+}
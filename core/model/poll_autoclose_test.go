@@ -0,0 +1,97 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPollAutoCloseAcceptsVote(t *testing.T) {
+	counters := PollCounters{Options: map[string]int{"0": 3, "1": 1}, UniqueVoters: 4}
+
+	tests := []struct {
+		name        string
+		autoClose   PollAutoClose
+		newVoter    bool
+		optionDelta int
+		want        bool
+	}{
+		{"no limits", PollAutoClose{}, true, 1, true},
+		{"new voter under max voters", PollAutoClose{MaxVoters: 5}, true, 0, true},
+		{"new voter at max voters", PollAutoClose{MaxVoters: 4}, true, 0, false},
+		{"changed vote at max voters", PollAutoClose{MaxVoters: 4}, false, 0, true},
+		{"vote for the option at option votes", PollAutoClose{Option: 0, OptionVotes: 3}, false, 1, false},
+		{"vote for another option at option votes", PollAutoClose{Option: 0, OptionVotes: 3}, true, 0, true},
+		{"vote moved away from the option at option votes", PollAutoClose{Option: 0, OptionVotes: 3}, false, -1, true},
+		{"vote for the option under option votes", PollAutoClose{Option: 1, OptionVotes: 3}, true, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.autoClose.AcceptsVote(counters, tt.newVoter, tt.optionDelta); got != tt.want {
+				t.Errorf("AcceptsVote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPollAutoCloseReopen(t *testing.T) {
+	tests := []struct {
+		name      string
+		autoClose PollAutoClose
+		raised    PollAutoClose
+		counters  PollCounters
+		newVoter  bool
+		delta     int
+	}{
+		{"max voters", PollAutoClose{MaxVoters: 2}, PollAutoClose{MaxVoters: 3},
+			PollCounters{Options: map[string]int{"0": 1, "1": 1}, UniqueVoters: 2}, true, 1},
+		{"option votes", PollAutoClose{Option: 1, OptionVotes: 2}, PollAutoClose{Option: 1, OptionVotes: 5},
+			PollCounters{Options: map[string]int{"0": 1, "1": 2}, UniqueVoters: 3}, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//the limit is reached, the poll is auto closed
+			reason := tt.autoClose.Reason(tt.counters)
+			if len(reason) == 0 {
+				t.Fatalf("Reason() is empty, want a reached limit")
+			}
+			transition, err := NewPollStatusTransition(nil, PollStatusStarted, PollActionEnd)
+			if err != nil {
+				t.Fatalf("NewPollStatusTransition(end) error = %v", err)
+			}
+
+			//the poll can not be reopened while the limit is reached
+			transition, err = NewPollStatusTransition(nil, transition.ToStatus, PollActionReopen)
+			if err != nil {
+				t.Fatalf("NewPollStatusTransition(reopen) error = %v", err)
+			}
+			if transition.ToStatus != PollStatusStarted {
+				t.Fatalf("reopen status = %s, want %s", transition.ToStatus, PollStatusStarted)
+			}
+			if err := tt.autoClose.ValidateStart(tt.counters); !errors.Is(err, ErrInvalidPollTransition) {
+				t.Fatalf("ValidateStart() error = %v, want %v", err, ErrInvalidPollTransition)
+			}
+
+			//the raised limit allows reopening and voting
+			if err := tt.raised.ValidateStart(tt.counters); err != nil {
+				t.Fatalf("ValidateStart() with the raised limit error = %v", err)
+			}
+			if !tt.raised.AcceptsVote(tt.counters, tt.newVoter, tt.delta) {
+				t.Errorf("AcceptsVote() with the raised limit = false, want true")
+			}
+		})
+	}
+}
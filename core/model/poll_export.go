@@ -0,0 +1,74 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+// PollOptionTotal represents the exported total of a poll option
+type PollOptionTotal struct {
+	Option string `json:"option"`
+	Count  int    `json:"count"`
+} // @name PollOptionTotal
+
+// PollExportVote represents an exported vote of a non-anonymous poll
+type PollExportVote struct {
+	UserID  string    `json:"user_id"`
+	Answer  []string  `json:"answer,omitempty"` // the option texts, for a ranked poll the most preferred first
+	Value   *float64  `json:"value,omitempty"`
+	Text    string    `json:"text,omitempty"`
+	Status  string    `json:"status,omitempty"` // the moderation status of a text answer
+	Created time.Time `json:"created"`
+} // @name PollExportVote
+
+// OptionTotals gets the totals per option. The totals of a rating or numeric poll are per scale value, a ranked poll
+// counts the most preferred options only and an open text poll has no options.
+func (r *PollResult) OptionTotals() []PollOptionTotal {
+	totals := []PollOptionTotal{}
+	if r.IsOpenText() {
+		return totals
+	}
+
+	if r.IsScale() {
+		if r.Stats != nil {
+			for _, bucket := range r.Stats.Histogram {
+				totals = append(totals, PollOptionTotal{Option: strconv.FormatFloat(bucket.Value, 'f', -1, 64), Count: bucket.Count})
+			}
+		}
+		return totals
+	}
+
+	for i, option := range r.Options {
+		total := PollOptionTotal{Option: option}
+		if i < len(r.Results) {
+			total.Count = r.Results[i]
+		}
+		totals = append(totals, total)
+	}
+	return totals
+}
+
+// ToPollExportVote converts a vote of the poll to PollExportVote
+func (pd *PollData) ToPollExportVote(userID string, vote PollVote) PollExportVote {
+	answer := make([]string, 0, len(vote.Answer))
+	for _, index := range vote.Answer {
+		if index >= 0 && index < len(pd.Options) {
+			answer = append(answer, pd.Options[index])
+		}
+	}
+	return PollExportVote{UserID: userID, Answer: answer, Value: vote.Value, Text: vote.Text, Status: vote.Status, Created: vote.Created}
+}
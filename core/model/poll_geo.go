@@ -0,0 +1,214 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// MaxGeoFenceRadius the max radius of a circular geo fence in meters
+	MaxGeoFenceRadius = 100000
+	// MaxGeoFencePolygonPoints the max number of points of a polygon geo fence
+	MaxGeoFencePolygonPoints = 100
+	// MaxVoteLocationAccuracy the worst accepted accuracy of the voter location in meters
+	MaxVoteLocationAccuracy = 500
+	// MaxGeoFenceTolerance the max distance in meters outside the geo region which is accepted for an inaccurate location
+	MaxGeoFenceTolerance = 50
+
+	// the mean earth radius in meters
+	earthRadius = 6371008.8
+)
+
+// ErrOutsideGeoFence the voter location is outside the poll geo fence
+var ErrOutsideGeoFence = errors.New("vote location is outside the poll geo fence")
+
+// GeoPoint represents a point on the earth
+type GeoPoint struct {
+	Latitude  float64 `json:"lat" bson:"lat"`
+	Longitude float64 `json:"lng" bson:"lng"`
+} // @name GeoPoint
+
+// Validate checks if the coordinates are in range
+func (p GeoPoint) Validate() error {
+	if math.IsNaN(p.Latitude) || p.Latitude < -90 || p.Latitude > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if math.IsNaN(p.Longitude) || p.Longitude < -180 || p.Longitude > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+	return nil
+}
+
+// PollGeoRegion represents the region where a geo-fenced poll can be voted. It is either a circle or a polygon.
+type PollGeoRegion struct {
+	Center  *GeoPoint  `json:"center,omitempty" bson:"center,omitempty"`
+	Radius  float64    `json:"radius,omitempty" bson:"radius,omitempty"`   // in meters
+	Polygon []GeoPoint `json:"polygon,omitempty" bson:"polygon,omitempty"` // the vertices in order, the last one is connected to the first one
+} // @name PollGeoRegion
+
+// Validate checks if the region is a valid circle or polygon
+func (r *PollGeoRegion) Validate() error {
+	if r.Center != nil && len(r.Polygon) > 0 {
+		return fmt.Errorf("%w: geo region must be either a circle or a polygon", ErrInvalidPoll)
+	}
+
+	if r.Center != nil {
+		err := r.Center.Validate()
+		if err != nil {
+			return fmt.Errorf("%w: geo region center - %s", ErrInvalidPoll, err)
+		}
+		if r.Radius <= 0 || r.Radius > MaxGeoFenceRadius {
+			return fmt.Errorf("%w: geo region radius must be positive and up to %d meters", ErrInvalidPoll, MaxGeoFenceRadius)
+		}
+		return nil
+	}
+
+	if len(r.Polygon) < 3 || len(r.Polygon) > MaxGeoFencePolygonPoints {
+		return fmt.Errorf("%w: geo region polygon must have between 3 and %d points", ErrInvalidPoll, MaxGeoFencePolygonPoints)
+	}
+	for _, point := range r.Polygon {
+		err := point.Validate()
+		if err != nil {
+			return fmt.Errorf("%w: geo region polygon - %s", ErrInvalidPoll, err)
+		}
+	}
+	return nil
+}
+
+// Distance gets the distance in meters from the point to the region, 0 if the point is inside
+func (r *PollGeoRegion) Distance(point GeoPoint) float64 {
+	if r.Center != nil {
+		return math.Max(0, haversineDistance(*r.Center, point)-r.Radius)
+	}
+
+	//the polygons are small, so they are projected on a plane around the point
+	vertices := make([][2]float64, len(r.Polygon))
+	for i, vertex := range r.Polygon {
+		vertices[i] = projectGeoPoint(point, vertex)
+	}
+
+	inside := false
+	distance := math.Inf(1)
+	for i := range vertices {
+		a := vertices[i]
+		b := vertices[(i+1)%len(vertices)]
+
+		//ray casting from the point, which is the origin of the plane
+		if (a[1] > 0) != (b[1] > 0) && a[0]+(0-a[1])*(b[0]-a[0])/(b[1]-a[1]) > 0 {
+			inside = !inside
+		}
+		distance = math.Min(distance, originSegmentDistance(a, b))
+	}
+
+	if inside {
+		return 0
+	}
+	return distance
+}
+
+// Tolerance gets the distance in meters outside the region which is accepted for a location with the accuracy.
+// The accuracy is reported by the voter, so it is capped by a fixed limit and by the radius of a circular region.
+func (r *PollGeoRegion) Tolerance(accuracy float64) float64 {
+	tolerance := math.Min(accuracy, MaxGeoFenceTolerance)
+	if r.Center != nil {
+		tolerance = math.Min(tolerance, r.Radius)
+	}
+	return tolerance
+}
+
+// VoteLocation represents the location reported by the voter
+type VoteLocation struct {
+	GeoPoint `bson:",inline"`
+	Accuracy float64 `json:"accuracy" bson:"accuracy"` // the radius of the uncertainty in meters
+} // @name VoteLocation
+
+// ValidateVoteLocation checks if the voter location is within the geo fence of the poll. The accuracy of the
+// location is in favour of the voter up to the region tolerance, so a location just outside the region is accepted.
+func (pd *PollData) ValidateVoteLocation(location *VoteLocation) error {
+	if !pd.Geo || pd.GeoRegion == nil {
+		// the polls created before the geo regions were introduced are fenced by the clients
+		return nil
+	}
+	if location == nil {
+		return fmt.Errorf("%w: location is required for geo-fenced polls", ErrInvalidVote)
+	}
+
+	err := location.Validate()
+	if err != nil {
+		return fmt.Errorf("%w: location - %s", ErrInvalidVote, err)
+	}
+	if math.IsNaN(location.Accuracy) || location.Accuracy < 0 || location.Accuracy > MaxVoteLocationAccuracy {
+		return fmt.Errorf("%w: location accuracy must be between 0 and %d meters", ErrInvalidVote, MaxVoteLocationAccuracy)
+	}
+
+	distance := pd.GeoRegion.Distance(location.GeoPoint)
+	if distance > pd.GeoRegion.Tolerance(location.Accuracy) {
+		return fmt.Errorf("%w: %.0f meters away", ErrOutsideGeoFence, distance)
+	}
+	return nil
+}
+
+// ValidateGeoFence checks if a geo fence has a region. The polls created before the geo regions were introduced
+// are fenced by the clients, so they stay valid without a region until the fence is changed.
+func (pd *PollData) ValidateGeoFence(previous *PollData) error {
+	if !pd.Geo || pd.GeoRegion != nil {
+		return nil
+	}
+	if previous != nil && previous.Geo && previous.GeoRegion == nil {
+		return nil
+	}
+	return fmt.Errorf("%w: geo region is required for geo-fenced polls", ErrInvalidPoll)
+}
+
+// haversineDistance gets the great-circle distance in meters
+func haversineDistance(a GeoPoint, b GeoPoint) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// projectGeoPoint projects the point on a plane with the origin at the center, x to the east and y to the north, in meters
+func projectGeoPoint(center GeoPoint, point GeoPoint) [2]float64 {
+	dLng := point.Longitude - center.Longitude
+	if dLng > 180 {
+		dLng -= 360
+	} else if dLng < -180 {
+		dLng += 360
+	}
+
+	x := dLng * math.Pi / 180 * math.Cos(center.Latitude*math.Pi/180) * earthRadius
+	y := (point.Latitude - center.Latitude) * math.Pi / 180 * earthRadius
+	return [2]float64{x, y}
+}
+
+// originSegmentDistance gets the distance from the origin to the segment
+func originSegmentDistance(a [2]float64, b [2]float64) float64 {
+	dx := b[0] - a[0]
+	dy := b[1] - a[1]
+	lengthSquared := dx*dx + dy*dy
+
+	t := 0.0
+	if lengthSquared > 0 {
+		t = math.Max(0, math.Min(1, -(a[0]*dx+a[1]*dy)/lengthSquared))
+	}
+	return math.Hypot(a[0]+t*dx, a[1]+t*dy)
+}
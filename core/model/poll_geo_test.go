@@ -0,0 +1,131 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// the meters per degree of latitude
+const metersPerLatitude = earthRadius * math.Pi / 180
+
+var testGeoCenter = GeoPoint{Latitude: 40.1020, Longitude: -88.2272}
+
+// northOf gets the point the distance in meters north of the center
+func northOf(center GeoPoint, meters float64) GeoPoint {
+	return GeoPoint{Latitude: center.Latitude + meters/metersPerLatitude, Longitude: center.Longitude}
+}
+
+func TestPollGeoRegionDistance(t *testing.T) {
+	circle := PollGeoRegion{Center: &testGeoCenter, Radius: 100}
+	square := PollGeoRegion{Polygon: []GeoPoint{
+		{Latitude: 40.1000, Longitude: -88.2300},
+		{Latitude: 40.1000, Longitude: -88.2200},
+		{Latitude: 40.1100, Longitude: -88.2200},
+		{Latitude: 40.1100, Longitude: -88.2300},
+	}}
+
+	tests := []struct {
+		name   string
+		region PollGeoRegion
+		point  GeoPoint
+		want   float64
+	}{
+		{"circle center", circle, testGeoCenter, 0},
+		{"inside circle", circle, northOf(testGeoCenter, 60), 0},
+		{"outside circle", circle, northOf(testGeoCenter, 250), 150},
+		{"inside polygon", square, GeoPoint{Latitude: 40.1050, Longitude: -88.2250}, 0},
+		{"north of polygon", square, GeoPoint{Latitude: 40.1100 + 200/metersPerLatitude, Longitude: -88.2250}, 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.region.Distance(tt.point); math.Abs(got-tt.want) > 1 {
+				t.Errorf("Distance() = %.1f, want %.1f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPollDataValidateVoteLocation(t *testing.T) {
+	circle := &PollGeoRegion{Center: &testGeoCenter, Radius: 100}
+	smallCircle := &PollGeoRegion{Center: &testGeoCenter, Radius: 20}
+
+	tests := []struct {
+		name     string
+		poll     PollData
+		location *VoteLocation
+		wantErr  error
+	}{
+		{"not geo-fenced", PollData{}, nil, nil},
+		{"geo-fenced by the clients", PollData{Geo: true}, nil, nil},
+		{"missing location", PollData{Geo: true, GeoRegion: circle}, nil, ErrInvalidVote},
+		{"invalid latitude", PollData{Geo: true, GeoRegion: circle},
+			&VoteLocation{GeoPoint: GeoPoint{Latitude: 91}}, ErrInvalidVote},
+		{"accuracy over the max", PollData{Geo: true, GeoRegion: circle},
+			&VoteLocation{GeoPoint: testGeoCenter, Accuracy: MaxVoteLocationAccuracy + 1}, ErrInvalidVote},
+		{"inside", PollData{Geo: true, GeoRegion: circle},
+			&VoteLocation{GeoPoint: northOf(testGeoCenter, 50)}, nil},
+		{"outside within the accuracy", PollData{Geo: true, GeoRegion: circle},
+			&VoteLocation{GeoPoint: northOf(testGeoCenter, 130), Accuracy: 40}, nil},
+		{"outside over the accuracy", PollData{Geo: true, GeoRegion: circle},
+			&VoteLocation{GeoPoint: northOf(testGeoCenter, 130), Accuracy: 10}, ErrOutsideGeoFence},
+		{"outside over the fixed tolerance", PollData{Geo: true, GeoRegion: circle},
+			&VoteLocation{GeoPoint: northOf(testGeoCenter, 180), Accuracy: MaxVoteLocationAccuracy}, ErrOutsideGeoFence},
+		{"outside over the radius tolerance", PollData{Geo: true, GeoRegion: smallCircle},
+			&VoteLocation{GeoPoint: northOf(testGeoCenter, 60), Accuracy: 100}, ErrOutsideGeoFence},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.poll.ValidateVoteLocation(tt.location)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("ValidateVoteLocation() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateVoteLocation() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPollDataValidateGeoFence(t *testing.T) {
+	region := &PollGeoRegion{Center: &testGeoCenter, Radius: 100}
+
+	tests := []struct {
+		name     string
+		poll     PollData
+		previous *PollData
+		wantErr  bool
+	}{
+		{"not geo-fenced", PollData{}, nil, false},
+		{"new fence with a region", PollData{Geo: true, GeoRegion: region}, nil, false},
+		{"new fence without a region", PollData{Geo: true}, nil, true},
+		{"fence added without a region", PollData{Geo: true}, &PollData{}, true},
+		{"region removed", PollData{Geo: true}, &PollData{Geo: true, GeoRegion: region}, true},
+		{"legacy fence without a region", PollData{Geo: true}, &PollData{Geo: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.poll.ValidateGeoFence(tt.previous)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateGeoFence() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidPoll) {
+				t.Errorf("ValidateGeoFence() error = %v, want %v", err, ErrInvalidPoll)
+			}
+		})
+	}
+}
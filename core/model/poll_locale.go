@@ -0,0 +1,144 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PollLocalization represents the question and the options of a poll in a locale
+type PollLocalization struct {
+	Question string   `json:"question" bson:"question"`
+	Options  []string `json:"options,omitempty" bson:"options,omitempty"` // the options of the default locale are used if empty
+} // @name PollLocalization
+
+// validateLocalizations checks if the localized variants fit the poll options
+func (pd *PollData) validateLocalizations() error {
+	for locale, localization := range pd.Localizations {
+		if len(NormalizeLocale(locale)) == 0 {
+			return fmt.Errorf("%w: empty localization locale", ErrInvalidPoll)
+		}
+		if len(strings.TrimSpace(localization.Question)) == 0 {
+			return fmt.Errorf("%w: the %s question is empty", ErrInvalidPoll, locale)
+		}
+		if len(localization.Options) > 0 && len(localization.Options) != len(pd.Options) {
+			return fmt.Errorf("%w: the %s options do not match the poll options", ErrInvalidPoll, locale)
+		}
+	}
+	return nil
+}
+
+// MatchLocale gets the key of the localized variant for the most preferred locale. The exact locale is matched first,
+// then the language. Returns an empty string if the default locale is preferred or there is no matching variant.
+func (pd *PollData) MatchLocale(locales []string) string {
+	defaultLocale := NormalizeLocale(pd.DefaultLocale)
+	for _, locale := range locales {
+		locale = NormalizeLocale(locale)
+		if len(locale) == 0 {
+			continue
+		}
+
+		if locale == defaultLocale {
+			return ""
+		}
+		if key := pd.localizationKey(func(key string) bool { return key == locale }); len(key) > 0 {
+			return key
+		}
+
+		language := localeLanguage(locale)
+		if len(defaultLocale) > 0 && language == localeLanguage(defaultLocale) {
+			return ""
+		}
+		if key := pd.localizationKey(func(key string) bool { return localeLanguage(key) == language }); len(key) > 0 {
+			return key
+		}
+	}
+	return ""
+}
+
+// localizationKey gets the key of the first localized variant whose normalized locale matches
+func (pd *PollData) localizationKey(match func(locale string) bool) string {
+	keys := make([]string, 0, len(pd.Localizations))
+	for key := range pd.Localizations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if match(NormalizeLocale(key)) {
+			return key
+		}
+	}
+	return ""
+}
+
+// Localize replaces the question and the options with the localized variant for the most preferred locale.
+// Returns the key of the applied variant, or an empty string if the default locale is kept.
+func (pd *PollData) Localize(locales []string) string {
+	key := pd.MatchLocale(locales)
+	if len(key) == 0 {
+		return ""
+	}
+
+	localization := pd.Localizations[key]
+	pd.Question = localization.Question
+	if len(localization.Options) > 0 {
+		pd.Options = append([]string{}, localization.Options...)
+	}
+	return key
+}
+
+// LocalizedQuestion gets the question of the localized variant, or the default question if the key is empty
+func (pd *PollData) LocalizedQuestion(key string) string {
+	if localization, ok := pd.Localizations[key]; ok && len(key) > 0 {
+		return localization.Question
+	}
+	return pd.Question
+}
+
+// Localize localizes the poll for the most preferred locale and sets the locale of the result
+func (r *PollResult) Localize(locales []string) {
+	r.Locale = r.PollData.Localize(locales)
+	if len(r.Locale) == 0 {
+		r.Locale = r.DefaultLocale
+	}
+}
+
+// NormalizeLocale normalizes a locale like en_US to en-us
+func NormalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// localeLanguage gets the language of a normalized locale like en-us
+func localeLanguage(locale string) string {
+	language, _, _ := strings.Cut(locale, "-")
+	return language
+}
+
+// GroupByLocale groups the members by the localized variant of the poll for their locale. The members with an unknown
+// locale or without a matching variant are grouped under an empty key, i.e. the default locale.
+func (t ToMembers) GroupByLocale(pd *PollData) map[string]ToMembers {
+	groups := map[string]ToMembers{}
+	for _, toMember := range t {
+		key := ""
+		if len(toMember.Locale) > 0 {
+			key = pd.MatchLocale([]string{toMember.Locale})
+		}
+		groups[key] = append(groups[key], toMember)
+	}
+	return groups
+}
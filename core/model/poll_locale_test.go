@@ -0,0 +1,135 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func testLocalizedPoll() PollData {
+	return PollData{
+		Question:      "Favorite color?",
+		Options:       []string{"red", "blue"},
+		DefaultLocale: "en-US",
+		Localizations: map[string]PollLocalization{
+			"es":    {Question: "¿Color favorito?", Options: []string{"rojo", "azul"}},
+			"fr_CA": {Question: "Couleur préférée?"},
+		},
+	}
+}
+
+func TestPollDataValidateLocalizations(t *testing.T) {
+	tests := []struct {
+		name          string
+		localizations map[string]PollLocalization
+		wantErr       bool
+	}{
+		{"none", nil, false},
+		{"options of the default locale", map[string]PollLocalization{"es": {Question: "¿Color?"}}, false},
+		{"localized options", map[string]PollLocalization{"es": {Question: "¿Color?", Options: []string{"rojo", "azul"}}}, false},
+		{"empty locale", map[string]PollLocalization{" ": {Question: "¿Color?"}}, true},
+		{"empty question", map[string]PollLocalization{"es": {Question: " "}}, true},
+		{"options count mismatch", map[string]PollLocalization{"es": {Question: "¿Color?", Options: []string{"rojo"}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := PollData{Options: []string{"red", "blue"}, Localizations: tt.localizations}
+			err := poll.validateLocalizations()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateLocalizations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidPoll) {
+				t.Errorf("validateLocalizations() error = %v, want %v", err, ErrInvalidPoll)
+			}
+		})
+	}
+}
+
+func TestPollDataMatchLocale(t *testing.T) {
+	tests := []struct {
+		name    string
+		locales []string
+		want    string
+	}{
+		{"no locales", nil, ""},
+		{"exact locale", []string{"es"}, "es"},
+		{"not normalized locale", []string{"FR-ca"}, "fr_CA"},
+		{"language", []string{"es-MX"}, "es"},
+		{"language of a regional variant", []string{"fr"}, "fr_CA"},
+		{"default locale", []string{"en-US", "es"}, ""},
+		{"default language", []string{"en-GB", "es"}, ""},
+		{"unknown locale", []string{"de"}, ""},
+		{"next preferred locale", []string{"de", "", "es"}, "es"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := testLocalizedPoll()
+			if got := poll.MatchLocale(tt.locales); got != tt.want {
+				t.Errorf("MatchLocale() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPollDataLocalize(t *testing.T) {
+	tests := []struct {
+		name         string
+		locales      []string
+		want         string
+		wantQuestion string
+		wantOptions  []string
+	}{
+		{"default locale", []string{"en"}, "", "Favorite color?", []string{"red", "blue"}},
+		{"localized options", []string{"es"}, "es", "¿Color favorito?", []string{"rojo", "azul"}},
+		{"options of the default locale", []string{"fr-CA"}, "fr_CA", "Couleur préférée?", []string{"red", "blue"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := testLocalizedPoll()
+			got := poll.Localize(tt.locales)
+			if got != tt.want || poll.Question != tt.wantQuestion || !slices.Equal(poll.Options, tt.wantOptions) {
+				t.Errorf("Localize() = %q %q %v, want %q %q %v", got, poll.Question, poll.Options, tt.want, tt.wantQuestion, tt.wantOptions)
+			}
+		})
+	}
+}
+
+func TestToMembersGroupByLocale(t *testing.T) {
+	poll := testLocalizedPoll()
+	members := ToMembers{
+		{UserID: "1", Locale: "es-ES"},
+		{UserID: "2"},
+		{UserID: "3", Locale: "de"},
+		{UserID: "4", Locale: "es"},
+		{UserID: "5", Locale: "fr_ca"},
+	}
+
+	groups := members.GroupByLocale(&poll)
+	want := map[string][]string{"": {"2", "3"}, "es": {"1", "4"}, "fr_CA": {"5"}}
+	if len(groups) != len(want) {
+		t.Fatalf("GroupByLocale() = %v, want %v", groups, want)
+	}
+	for key, userIDs := range want {
+		got := []string{}
+		for _, member := range groups[key] {
+			got = append(got, member.UserID)
+		}
+		if !slices.Equal(got, userIDs) {
+			t.Errorf("GroupByLocale()[%q] = %v, want %v", key, got, userIDs)
+		}
+	}
+}
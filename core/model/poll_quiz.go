@@ -0,0 +1,168 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidLeaderboardScope the leaderboard is requested for none or both of a group and a session
+var ErrInvalidLeaderboardScope = errors.New("invalid leaderboard scope")
+
+// DefaultQuizPoints the points of a correct answer when the quiz points are not set
+const DefaultQuizPoints = 1
+
+// PollQuiz represents the correct answer of a quiz poll. The correct options are revealed to the voters once the poll ends.
+type PollQuiz struct {
+	CorrectOptions []int `json:"correct_options" bson:"correct_options"`   // the answer is correct if it selects exactly these options
+	Points         int   `json:"points,omitempty" bson:"points,omitempty"` // the points of a correct answer, 1 if not set
+} // @name PollQuiz
+
+// Validate checks if the correct options fit the poll options and choice rules
+func (q *PollQuiz) Validate(pd *PollData) error {
+	if len(pd.PollType) > 0 && pd.PollType != PollTypeChoice {
+		return fmt.Errorf("%w: quiz is supported for choice polls only", ErrInvalidPoll)
+	}
+	if pd.Anonymous {
+		return fmt.Errorf("%w: quiz polls can not be anonymous", ErrInvalidPoll)
+	}
+	if q.Points < 0 {
+		return fmt.Errorf("%w: quiz points must not be negative", ErrInvalidPoll)
+	}
+	if len(q.CorrectOptions) == 0 {
+		return fmt.Errorf("%w: quiz correct options are required", ErrInvalidPoll)
+	}
+	if !pd.MultiChoice && len(q.CorrectOptions) > 1 {
+		return fmt.Errorf("%w: the poll allows a single correct option", ErrInvalidPoll)
+	}
+
+	correct := map[int]bool{}
+	for _, option := range q.CorrectOptions {
+		if option < 0 || option >= len(pd.Options) {
+			return fmt.Errorf("%w: quiz correct option %d is not a poll option", ErrInvalidPoll, option)
+		}
+		if correct[option] {
+			return fmt.Errorf("%w: quiz correct option %d is set more than once", ErrInvalidPoll, option)
+		}
+		correct[option] = true
+	}
+	return nil
+}
+
+// IsCorrect checks if the answer selects exactly the correct options
+func (q *PollQuiz) IsCorrect(answer []int) bool {
+	if len(answer) != len(q.CorrectOptions) {
+		return false
+	}
+
+	correct := map[int]bool{}
+	for _, option := range q.CorrectOptions {
+		correct[option] = true
+	}
+	for _, option := range answer {
+		if !correct[option] {
+			return false
+		}
+		delete(correct, option)
+	}
+	return true
+}
+
+// GetPoints gets the points of a correct answer
+func (q *PollQuiz) GetPoints() int {
+	if q.Points > 0 {
+		return q.Points
+	}
+	return DefaultQuizPoints
+}
+
+// copy copies the quiz without the correct options if they are hidden
+func (q *PollQuiz) copy(hideCorrectOptions bool) *PollQuiz {
+	quiz := PollQuiz{Points: q.Points}
+	if !hideCorrectOptions {
+		quiz.CorrectOptions = append([]int{}, q.CorrectOptions...)
+	}
+	return &quiz
+}
+
+// QuizScore represents the score of a user for a quiz poll. The scores are calculated when the poll ends.
+type QuizScore struct {
+	ID          string    `json:"id" bson:"_id"`
+	OrgID       string    `json:"org_id" bson:"org_id"`
+	PollID      string    `json:"poll_id" bson:"poll_id"`
+	UserID      string    `json:"user_id" bson:"user_id"`
+	GroupID     *string   `json:"group_id,omitempty" bson:"group_id,omitempty"`
+	SessionID   string    `json:"session_id,omitempty" bson:"session_id,omitempty"`
+	Correct     bool      `json:"correct" bson:"correct"`
+	Points      int       `json:"points" bson:"points"`
+	DateCreated time.Time `json:"date_created" bson:"date_created"`
+} // @name QuizScore
+
+// NewQuizScores calculates the scores of the voters of an ended quiz poll. The last vote of a user is scored.
+func NewQuizScores(poll *Poll, votes []PollUserVotes) []QuizScore {
+	if poll.Quiz == nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	scores := make([]QuizScore, 0, len(votes))
+	for _, userVotes := range votes {
+		if len(userVotes.Votes) == 0 {
+			continue
+		}
+
+		score := QuizScore{ID: fmt.Sprintf("%s_%s", poll.ID.Hex(), userVotes.UserID), OrgID: poll.OrgID, PollID: poll.ID.Hex(),
+			UserID: userVotes.UserID, GroupID: poll.GroupID, SessionID: poll.SessionID, DateCreated: now}
+		if poll.Quiz.IsCorrect(userVotes.Votes[len(userVotes.Votes)-1].Answer) {
+			score.Correct = true
+			score.Points = poll.Quiz.GetPoints()
+		}
+		scores = append(scores, score)
+	}
+	return scores
+}
+
+// QuizLeaderboard represents the scores of the users across the quiz polls of a group or a session
+type QuizLeaderboard struct {
+	GroupID   *string                `json:"group_id,omitempty"`
+	SessionID string                 `json:"session_id,omitempty"`
+	Entries   []QuizLeaderboardEntry `json:"entries"` // the highest score first
+} // @name QuizLeaderboard
+
+// QuizLeaderboardEntry represents the total score of a user
+type QuizLeaderboardEntry struct {
+	Rank           int    `json:"rank" bson:"-"` // the users with the same points and correct answers share the rank
+	UserID         string `json:"user_id" bson:"_id"`
+	Points         int    `json:"points" bson:"points"`
+	CorrectAnswers int    `json:"correct_answers" bson:"correct_answers"`
+	Answered       int    `json:"answered" bson:"answered"` // the number of the scored quiz polls the user has voted in
+} // @name QuizLeaderboardEntry
+
+// SetRanks sets the ranks of the entries sorted by the highest score first
+func (l *QuizLeaderboard) SetRanks() {
+	for i := range l.Entries {
+		entry := &l.Entries[i]
+		if i > 0 {
+			previous := l.Entries[i-1]
+			if previous.Points == entry.Points && previous.CorrectAnswers == entry.CorrectAnswers {
+				entry.Rank = previous.Rank
+				continue
+			}
+		}
+		entry.Rank = i + 1
+	}
+}
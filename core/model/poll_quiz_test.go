@@ -0,0 +1,149 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPollQuizValidate(t *testing.T) {
+	options := []string{"a", "b", "c"}
+
+	tests := []struct {
+		name    string
+		poll    PollData
+		quiz    PollQuiz
+		wantErr bool
+	}{
+		{"single correct option", PollData{Options: options}, PollQuiz{CorrectOptions: []int{1}}, false},
+		{"many correct options", PollData{Options: options, MultiChoice: true}, PollQuiz{CorrectOptions: []int{0, 2}, Points: 3}, false},
+		{"choice type", PollData{Options: options, PollType: PollTypeChoice}, PollQuiz{CorrectOptions: []int{0}}, false},
+		{"ranked poll", PollData{Options: options, PollType: PollTypeRanked}, PollQuiz{CorrectOptions: []int{0}}, true},
+		{"anonymous poll", PollData{Options: options, Anonymous: true}, PollQuiz{CorrectOptions: []int{0}}, true},
+		{"negative points", PollData{Options: options}, PollQuiz{CorrectOptions: []int{0}, Points: -1}, true},
+		{"no correct options", PollData{Options: options}, PollQuiz{}, true},
+		{"many correct options of a single choice", PollData{Options: options}, PollQuiz{CorrectOptions: []int{0, 1}}, true},
+		{"option out of range", PollData{Options: options}, PollQuiz{CorrectOptions: []int{3}}, true},
+		{"duplicate option", PollData{Options: options, MultiChoice: true}, PollQuiz{CorrectOptions: []int{1, 1}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.quiz.Validate(&tt.poll)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidPoll) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalidPoll)
+			}
+		})
+	}
+}
+
+func TestPollQuizIsCorrect(t *testing.T) {
+	quiz := PollQuiz{CorrectOptions: []int{0, 2}}
+
+	tests := []struct {
+		name   string
+		answer []int
+		want   bool
+	}{
+		{"exact", []int{0, 2}, true},
+		{"other order", []int{2, 0}, true},
+		{"partial", []int{0}, false},
+		{"extra option", []int{0, 1, 2}, false},
+		{"wrong option", []int{0, 1}, false},
+		{"duplicate option", []int{0, 0}, false},
+		{"empty", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quiz.IsCorrect(tt.answer); got != tt.want {
+				t.Errorf("IsCorrect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewQuizScores(t *testing.T) {
+	poll := Poll{ID: primitive.NewObjectID(), OrgID: "org", PollData: PollData{Quiz: &PollQuiz{CorrectOptions: []int{1}, Points: 5}}}
+	votes := []PollUserVotes{
+		{UserID: "correct", Votes: []PollVote{{Answer: []int{1}}}},
+		{UserID: "wrong", Votes: []PollVote{{Answer: []int{0}}}},
+		{UserID: "changed to correct", Votes: []PollVote{{Answer: []int{0}}, {Answer: []int{1}}}},
+		{UserID: "changed to wrong", Votes: []PollVote{{Answer: []int{1}}, {Answer: []int{2}}}},
+		{UserID: "retracted"},
+	}
+
+	tests := []struct {
+		name   string
+		poll   Poll
+		want   map[string]int // the points per user
+		wantOk bool
+	}{
+		{"quiz", poll, map[string]int{"correct": 5, "wrong": 0, "changed to correct": 5, "changed to wrong": 0}, true},
+		{"not a quiz", Poll{ID: poll.ID}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scores := NewQuizScores(&tt.poll, votes)
+			if (scores != nil) != tt.wantOk || len(scores) != len(tt.want) {
+				t.Fatalf("NewQuizScores() = %v, want %v", scores, tt.want)
+			}
+			for _, score := range scores {
+				points, ok := tt.want[score.UserID]
+				if !ok || score.Points != points || score.Correct != (points > 0) {
+					t.Errorf("NewQuizScores() %s = %d (%v), want %d", score.UserID, score.Points, score.Correct, points)
+				}
+				if score.PollID != poll.ID.Hex() || score.ID != poll.ID.Hex()+"_"+score.UserID {
+					t.Errorf("NewQuizScores() %s id = %s, poll id = %s", score.UserID, score.ID, score.PollID)
+				}
+			}
+		})
+	}
+}
+
+func TestQuizLeaderboardSetRanks(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []QuizLeaderboardEntry
+		want    []int
+	}{
+		{"empty", nil, []int{}},
+		{"distinct scores", []QuizLeaderboardEntry{{Points: 5, CorrectAnswers: 2}, {Points: 3, CorrectAnswers: 2}, {Points: 1, CorrectAnswers: 1}},
+			[]int{1, 2, 3}},
+		{"shared rank", []QuizLeaderboardEntry{{Points: 5, CorrectAnswers: 2}, {Points: 3, CorrectAnswers: 1}, {Points: 3, CorrectAnswers: 1},
+			{Points: 1, CorrectAnswers: 1}}, []int{1, 2, 2, 4}},
+		{"same points with other correct answers", []QuizLeaderboardEntry{{Points: 4, CorrectAnswers: 4}, {Points: 4, CorrectAnswers: 1}},
+			[]int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaderboard := QuizLeaderboard{Entries: tt.entries}
+			leaderboard.SetRanks()
+
+			got := []int{}
+			for _, entry := range leaderboard.Entries {
+				got = append(got, entry.Rank)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SetRanks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// PollRecurrenceDaily a new instance is created every interval days
+	PollRecurrenceDaily = "daily"
+	// PollRecurrenceWeekly a new instance is created on the weekdays of every interval weeks
+	PollRecurrenceWeekly = "weekly"
+	// PollRecurrenceCustom a new instance is created on the cron-like schedule
+	PollRecurrenceCustom = "custom"
+
+	// PollSeriesStatusActive the series creates its instances on schedule. It is the default status.
+	PollSeriesStatusActive = "active"
+	// PollSeriesStatusPaused the series creates no instances until it is active again, the missed instances are not created
+	PollSeriesStatusPaused = "paused"
+	// PollSeriesStatusEnded the series creates no more instances
+	PollSeriesStatusEnded = "ended"
+)
+
+// maxRecurrenceSearchDays the furthest the next occurrence of a custom schedule is searched
+const maxRecurrenceSearchDays = 5 * 366
+
+// PollRecurrence represents the rule which creates a fresh instance of a poll on schedule. The recurring poll is the
+// first poll of the series, the instances copy its content, owner, members and group. It is not related to
+// PollData.Repeat, which allows the same user to vote a poll more than once. The status of the series is not the
+// status of the recurring poll, which ends like any instance, so the series is paused or ended by its own status.
+type PollRecurrence struct {
+	Frequency    string     `json:"frequency" bson:"frequency"`                             // daily, weekly or custom
+	Interval     int        `json:"interval,omitempty" bson:"interval,omitempty"`           // every n days or weeks, 1 if not set
+	Weekdays     []int      `json:"weekdays,omitempty" bson:"weekdays,omitempty"`           // the days of a weekly recurrence, 0 is Sunday. The weekday of the first poll if not set.
+	Schedule     string     `json:"schedule,omitempty" bson:"schedule,omitempty"`           // the custom schedule: minute hour day-of-month month day-of-week, e.g. 0 9 * * 1-5
+	Timezone     string     `json:"timezone,omitempty" bson:"timezone,omitempty"`           // the IANA time zone of the schedule, UTC if not set
+	Duration     int        `json:"duration,omitempty" bson:"duration,omitempty"`           // the minutes an instance is open, until the next instance if not set
+	EndDate      *time.Time `json:"end_date,omitempty" bson:"end_date,omitempty"`           // no instance is created after this time
+	MaxInstances int        `json:"max_instances,omitempty" bson:"max_instances,omitempty"` // the max number of polls in the series, the first poll included
+	Status       string     `json:"status,omitempty" bson:"status,omitempty"`               // active (default), paused or ended
+} // @name PollRecurrence
+
+// Validate checks if the recurrence rule is complete and its schedule occurs
+func (r *PollRecurrence) Validate() error {
+	switch r.Frequency {
+	case PollRecurrenceDaily, PollRecurrenceWeekly:
+		if len(r.Schedule) > 0 {
+			return fmt.Errorf("%w: the recurrence schedule is supported for custom recurrence only", ErrInvalidPoll)
+		}
+	case PollRecurrenceCustom:
+		if r.Interval > 1 {
+			return fmt.Errorf("%w: the recurrence interval is supported for daily and weekly recurrence only", ErrInvalidPoll)
+		}
+		schedule, err := parseCronSchedule(r.Schedule)
+		if err != nil {
+			return fmt.Errorf("%w: recurrence schedule - %s", ErrInvalidPoll, err)
+		}
+		if _, ok := schedule.next(time.Now().UTC()); !ok {
+			return fmt.Errorf("%w: the recurrence schedule never occurs", ErrInvalidPoll)
+		}
+	default:
+		return fmt.Errorf("%w: unknown recurrence frequency %s", ErrInvalidPoll, r.Frequency)
+	}
+
+	if len(r.Weekdays) > 0 && r.Frequency != PollRecurrenceWeekly {
+		return fmt.Errorf("%w: the recurrence weekdays are supported for weekly recurrence only", ErrInvalidPoll)
+	}
+	for _, weekday := range r.Weekdays {
+		if weekday < 0 || weekday > 6 {
+			return fmt.Errorf("%w: recurrence weekday %d must be between 0 and 6", ErrInvalidPoll, weekday)
+		}
+	}
+	switch r.Status {
+	case "", PollSeriesStatusActive, PollSeriesStatusPaused, PollSeriesStatusEnded:
+	default:
+		return fmt.Errorf("%w: unknown recurrence status %s", ErrInvalidPoll, r.Status)
+	}
+	if r.Interval < 0 || r.Duration < 0 || r.MaxInstances < 0 {
+		return fmt.Errorf("%w: the recurrence interval, duration and max instances must not be negative", ErrInvalidPoll)
+	}
+	if _, err := time.LoadLocation(r.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown recurrence time zone %s", ErrInvalidPoll, r.Timezone)
+	}
+	return nil
+}
+
+// IsActive checks if the series creates its instances
+func (r *PollRecurrence) IsActive() bool {
+	return len(r.Status) == 0 || r.Status == PollSeriesStatusActive
+}
+
+// Next gets the time of the next instance after the time, or nil if the series is complete or not active. The daily and weekly
+// instances are created at the time of day of the anchor, i.e. the start of the first poll. The instances are the polls created so far.
+func (r *PollRecurrence) Next(anchor time.Time, after time.Time, instances int) *time.Time {
+	if !r.IsActive() || (r.MaxInstances > 0 && instances >= r.MaxInstances) {
+		return nil
+	}
+
+	location, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	anchor = anchor.Truncate(time.Minute).In(location)
+	after = after.In(location)
+
+	var next time.Time
+	found := false
+	switch r.Frequency {
+	case PollRecurrenceDaily:
+		next, found = r.nextDaily(anchor, after)
+	case PollRecurrenceWeekly:
+		next, found = r.nextWeekly(anchor, after)
+	case PollRecurrenceCustom:
+		schedule, err := parseCronSchedule(r.Schedule)
+		if err == nil {
+			next, found = schedule.next(after)
+		}
+	}
+	if !found || (r.EndDate != nil && next.After(*r.EndDate)) {
+		return nil
+	}
+
+	next = next.UTC()
+	return &next
+}
+
+// InstanceEnd gets the end time of an instance started at the time. An instance without a duration ends when the next instance starts.
+func (r *PollRecurrence) InstanceEnd(start time.Time, next *time.Time) *time.Time {
+	if r.Duration > 0 {
+		end := start.Add(time.Duration(r.Duration) * time.Minute)
+		return &end
+	}
+	if next != nil {
+		end := *next
+		return &end
+	}
+	return nil
+}
+
+func (r *PollRecurrence) getInterval() int {
+	if r.Interval > 0 {
+		return r.Interval
+	}
+	return 1
+}
+
+func (r *PollRecurrence) nextDaily(anchor time.Time, after time.Time) (time.Time, bool) {
+	interval := r.getInterval()
+
+	days := 0
+	if after.After(anchor) {
+		// a day before, the days are shorter or longer on the daylight saving changes
+		days = max(0, calendarDays(anchor, after)-1) / interval * interval
+	}
+	for {
+		next := anchor.AddDate(0, 0, days)
+		if next.After(after) {
+			return next, true
+		}
+		days += interval
+	}
+}
+
+func (r *PollRecurrence) nextWeekly(anchor time.Time, after time.Time) (time.Time, bool) {
+	interval := r.getInterval()
+	weekdays := r.Weekdays
+	if len(weekdays) == 0 {
+		weekdays = []int{int(anchor.Weekday())}
+	}
+
+	days := 0
+	if after.After(anchor) {
+		days = max(0, calendarDays(anchor, after)-1)
+	}
+	// the weeks start on Sunday, every interval weeks from the week of the anchor
+	weekStart := int(anchor.Weekday())
+	for limit := days + 7*(interval+1); days <= limit; days++ {
+		next := anchor.AddDate(0, 0, days)
+		week := (days + weekStart) / 7
+		if week%interval == 0 && slices.Contains(weekdays, int(next.Weekday())) && next.After(after) {
+			return next, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// calendarDays gets the number of the calendar days from the date of the time to the date of the other time
+func calendarDays(from time.Time, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
+
+// cronSchedule represents a parsed cron-like schedule. A field contains the allowed values.
+type cronSchedule struct {
+	minutes    []bool
+	hours      []bool
+	days       []bool
+	months     []bool
+	weekdays   []bool
+	anyDay     bool
+	anyWeekday bool
+}
+
+// parseCronSchedule parses a schedule of five fields: minute hour day-of-month month day-of-week. A field is a list of
+// values, ranges and steps like 1,15 or 9-17 or */2. The weekday 7 is Sunday as well as 0.
+func parseCronSchedule(value string) (*cronSchedule, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("5 fields are expected: minute hour day-of-month month day-of-week")
+	}
+
+	var schedule cronSchedule
+	var err error
+	if schedule.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("minute %s", err)
+	}
+	if schedule.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("hour %s", err)
+	}
+	if schedule.days, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("day-of-month %s", err)
+	}
+	if schedule.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("month %s", err)
+	}
+	if schedule.weekdays, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("day-of-week %s", err)
+	}
+	schedule.weekdays[0] = schedule.weekdays[0] || schedule.weekdays[7]
+	schedule.anyDay = strings.HasPrefix(fields[2], "*")
+	schedule.anyWeekday = strings.HasPrefix(fields[4], "*")
+	return &schedule, nil
+}
+
+// parseCronField parses the allowed values of a schedule field
+func parseCronField(field string, lowest int, highest int) ([]bool, error) {
+	values := make([]bool, highest+1)
+	for _, part := range strings.Split(field, ",") {
+		valueRange, stepValue, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepValue)
+			if err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step %s", stepValue)
+			}
+		}
+
+		from, to := lowest, highest
+		if valueRange != "*" {
+			fromValue, toValue, isRange := strings.Cut(valueRange, "-")
+			var err error
+			from, err = strconv.Atoi(fromValue)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %s", valueRange)
+			}
+			to = from
+			if isRange {
+				to, err = strconv.Atoi(toValue)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value %s", valueRange)
+				}
+			} else if hasStep {
+				to = highest
+			}
+		}
+		if from < lowest || to > highest || from > to {
+			return nil, fmt.Errorf("%s is out of range %d-%d", valueRange, lowest, highest)
+		}
+
+		for value := from; value <= to; value += step {
+			values[value] = true
+		}
+	}
+	return values, nil
+}
+
+// next gets the first time of the schedule after the time, in the location of the time
+func (s *cronSchedule) next(after time.Time) (time.Time, bool) {
+	location := after.Location()
+	date := time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, location)
+	for i := 0; i <= maxRecurrenceSearchDays; i++ {
+		day := date.AddDate(0, 0, i)
+		if !s.matchesDay(day) {
+			continue
+		}
+		for hour := range s.hours {
+			if !s.hours[hour] {
+				continue
+			}
+			for minute := range s.minutes {
+				if !s.minutes[minute] {
+					continue
+				}
+				next := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, location)
+				if next.After(after) {
+					return next, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// matchesDay checks the date fields. Like cron, a day matches either field when both the day of month and the weekday are restricted.
+func (s *cronSchedule) matchesDay(day time.Time) bool {
+	if !s.months[int(day.Month())] {
+		return false
+	}
+	dayMatches := s.days[day.Day()]
+	weekdayMatches := s.weekdays[int(day.Weekday())]
+	if !s.anyDay && !s.anyWeekday {
+		return dayMatches || weekdayMatches
+	}
+	return dayMatches && weekdayMatches
+}
+
+// RecurrenceAnchor gets the time the recurrence of the poll is counted from, the scheduled start or the creation of the poll
+func (poll *Poll) RecurrenceAnchor() time.Time {
+	if poll.StartAt != nil {
+		return *poll.StartAt
+	}
+	return poll.DateCreated
+}
+
+// PollSeries represents the polls of a recurring series, so the results of the instances can be compared over time
+type PollSeries struct {
+	SeriesID       string          `json:"series_id"` // the id of the first poll of the series
+	Recurrence     *PollRecurrence `json:"recurrence,omitempty"`
+	NextInstanceAt *time.Time      `json:"next_instance_at,omitempty"`
+	Instances      []PollResult    `json:"instances"` // oldest first, the first poll included
+} // @name PollSeries
+
+// NewPollSeries creates the series of the polls with the results the current user can see
+func NewPollSeries(seriesID string, polls []Poll, currentUserID string) PollSeries {
+	series := PollSeries{SeriesID: seriesID, Instances: make([]PollResult, 0, len(polls))}
+	for i := range polls {
+		poll := &polls[i]
+		if poll.ID.Hex() == seriesID {
+			series.Recurrence = poll.Recurrence
+			series.NextInstanceAt = poll.NextInstanceAt
+		}
+		series.Instances = append(series.Instances, poll.ToPollResult(currentUserID))
+	}
+	return series
+}
@@ -0,0 +1,134 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPollRecurrenceValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		recurrence PollRecurrence
+		wantErr    bool
+	}{
+		{"daily", PollRecurrence{Frequency: PollRecurrenceDaily, Interval: 2}, false},
+		{"weekly on weekdays", PollRecurrence{Frequency: PollRecurrenceWeekly, Weekdays: []int{1, 3, 5}}, false},
+		{"custom", PollRecurrence{Frequency: PollRecurrenceCustom, Schedule: "0 9 * * 1-5", Timezone: "America/Chicago"}, false},
+		{"paused", PollRecurrence{Frequency: PollRecurrenceDaily, Status: PollSeriesStatusPaused}, false},
+		{"unknown frequency", PollRecurrence{Frequency: "hourly"}, true},
+		{"schedule of a daily recurrence", PollRecurrence{Frequency: PollRecurrenceDaily, Schedule: "0 9 * * *"}, true},
+		{"interval of a custom recurrence", PollRecurrence{Frequency: PollRecurrenceCustom, Schedule: "0 9 * * *", Interval: 2}, true},
+		{"weekdays of a daily recurrence", PollRecurrence{Frequency: PollRecurrenceDaily, Weekdays: []int{1}}, true},
+		{"weekday out of range", PollRecurrence{Frequency: PollRecurrenceWeekly, Weekdays: []int{7}}, true},
+		{"minute out of range", PollRecurrence{Frequency: PollRecurrenceCustom, Schedule: "60 9 * * *"}, true},
+		{"missing field", PollRecurrence{Frequency: PollRecurrenceCustom, Schedule: "0 9 * *"}, true},
+		{"invalid step", PollRecurrence{Frequency: PollRecurrenceCustom, Schedule: "*/0 9 * * *"}, true},
+		{"never occurs", PollRecurrence{Frequency: PollRecurrenceCustom, Schedule: "0 9 31 2 *"}, true},
+		{"negative duration", PollRecurrence{Frequency: PollRecurrenceDaily, Duration: -1}, true},
+		{"unknown time zone", PollRecurrence{Frequency: PollRecurrenceDaily, Timezone: "Mars/Olympus"}, true},
+		{"unknown status", PollRecurrence{Frequency: PollRecurrenceDaily, Status: "stopped"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.recurrence.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidPoll) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalidPoll)
+			}
+		})
+	}
+}
+
+func TestPollRecurrenceNext(t *testing.T) {
+	// a Monday
+	anchor := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	endDate := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		recurrence PollRecurrence
+		anchor     time.Time
+		after      time.Time
+		instances  int
+		want       *time.Time
+	}{
+		{"daily at the anchor", PollRecurrence{Frequency: PollRecurrenceDaily}, anchor, anchor, 1,
+			ptr(time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC))},
+		{"daily later", PollRecurrence{Frequency: PollRecurrenceDaily}, anchor, time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC), 1,
+			ptr(time.Date(2026, 3, 11, 9, 0, 0, 0, time.UTC))},
+		{"every other day", PollRecurrence{Frequency: PollRecurrenceDaily, Interval: 2}, anchor, time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC), 1,
+			ptr(time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC))},
+		{"daily over the daylight saving change", PollRecurrence{Frequency: PollRecurrenceDaily, Timezone: "America/Chicago"},
+			time.Date(2026, 3, 7, 15, 0, 0, 0, time.UTC), time.Date(2026, 3, 7, 15, 0, 0, 0, time.UTC), 1,
+			ptr(time.Date(2026, 3, 8, 14, 0, 0, 0, time.UTC))},
+		{"weekly on the anchor weekday", PollRecurrence{Frequency: PollRecurrenceWeekly}, anchor, anchor, 1,
+			ptr(time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC))},
+		{"weekly on weekdays", PollRecurrence{Frequency: PollRecurrenceWeekly, Weekdays: []int{1, 3}}, anchor, anchor, 1,
+			ptr(time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC))},
+		{"every other week", PollRecurrence{Frequency: PollRecurrenceWeekly, Interval: 2}, anchor, anchor, 1,
+			ptr(time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC))},
+		{"custom on workdays", PollRecurrence{Frequency: PollRecurrenceCustom, Schedule: "0 9 * * 1-5"}, anchor,
+			time.Date(2026, 3, 6, 10, 0, 0, 0, time.UTC), 1, ptr(time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC))},
+		{"custom monthly", PollRecurrence{Frequency: PollRecurrenceCustom, Schedule: "30 8 1 * *"}, anchor, anchor, 1,
+			ptr(time.Date(2026, 4, 1, 8, 30, 0, 0, time.UTC))},
+		{"custom on Sunday as 7", PollRecurrence{Frequency: PollRecurrenceCustom, Schedule: "0 12 * * 7"}, anchor, anchor, 1,
+			ptr(time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC))},
+		{"max instances reached", PollRecurrence{Frequency: PollRecurrenceDaily, MaxInstances: 3}, anchor, anchor, 3, nil},
+		{"after the end date", PollRecurrence{Frequency: PollRecurrenceDaily, EndDate: &endDate}, anchor, anchor, 1, nil},
+		{"paused series", PollRecurrence{Frequency: PollRecurrenceDaily, Status: PollSeriesStatusPaused}, anchor, anchor, 1, nil},
+		{"ended series", PollRecurrence{Frequency: PollRecurrenceDaily, Status: PollSeriesStatusEnded}, anchor, anchor, 1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.recurrence.Next(tt.anchor, tt.after, tt.instances)
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPollRecurrenceInstanceEnd(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	next := time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		recurrence PollRecurrence
+		next       *time.Time
+		want       *time.Time
+	}{
+		{"duration", PollRecurrence{Duration: 30}, &next, ptr(start.Add(30 * time.Minute))},
+		{"until the next instance", PollRecurrence{}, &next, &next},
+		{"last instance", PollRecurrence{}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.recurrence.InstanceEnd(start, tt.next)
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Errorf("InstanceEnd() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// RunoffRound represents the standings of an instant-runoff round
+type RunoffRound struct {
+	Round      int   `json:"round" bson:"round"`
+	Counts     []int `json:"counts" bson:"counts"`         // votes per option, the already eliminated options have 0
+	Eliminated []int `json:"eliminated" bson:"eliminated"` // the options eliminated at the end of the round
+	Exhausted  int   `json:"exhausted" bson:"exhausted"`   // the ballots which do not rank any remaining option
+} // @name RunoffRound
+
+// RunoffResult represents the instant-runoff tally of a ranked poll
+type RunoffResult struct {
+	Rounds   []RunoffRound `json:"rounds" bson:"rounds"`
+	Winner   *int          `json:"winner,omitempty" bson:"winner,omitempty"` // nil if there are no votes or the last options are tied
+	Revision int           `json:"-" bson:"revision"`                        // the counters revision the tally is calculated for
+} // @name RunoffResult
+
+// CalculateInstantRunoff calculates the instant-runoff tally. Every ballot is a ranking of option indexes, most preferred first.
+// In every round the ballots count for their most preferred remaining option. An option with more than half of the counted
+// ballots wins, otherwise the options with the fewest votes are eliminated and the next round starts.
+func CalculateInstantRunoff(optionsCount int, ballots [][]int) RunoffResult {
+	result := RunoffResult{Rounds: []RunoffRound{}}
+
+	remaining := make(map[int]bool, optionsCount)
+	for i := 0; i < optionsCount; i++ {
+		remaining[i] = true
+	}
+
+	for len(remaining) > 0 {
+		round := RunoffRound{Round: len(result.Rounds) + 1, Counts: make([]int, optionsCount), Eliminated: []int{}}
+
+		counted := 0
+		for _, ballot := range ballots {
+			preference := -1
+			for _, option := range ballot {
+				if remaining[option] {
+					preference = option
+					break
+				}
+			}
+
+			if preference < 0 {
+				round.Exhausted++
+				continue
+			}
+			round.Counts[preference]++
+			counted++
+		}
+
+		if counted == 0 {
+			// no votes
+			result.Rounds = append(result.Rounds, round)
+			break
+		}
+
+		//check for a majority
+		for option := range remaining {
+			if round.Counts[option]*2 > counted || len(remaining) == 1 {
+				winner := option
+				result.Winner = &winner
+				break
+			}
+		}
+		if result.Winner != nil {
+			result.Rounds = append(result.Rounds, round)
+			break
+		}
+
+		//eliminate the options with the fewest votes
+		fewest := -1
+		for option := range remaining {
+			if fewest < 0 || round.Counts[option] < fewest {
+				fewest = round.Counts[option]
+			}
+		}
+		for option := 0; option < optionsCount; option++ {
+			if remaining[option] && round.Counts[option] == fewest {
+				round.Eliminated = append(round.Eliminated, option)
+			}
+		}
+		result.Rounds = append(result.Rounds, round)
+
+		if len(round.Eliminated) == len(remaining) {
+			// all remaining options are tied
+			break
+		}
+		for _, option := range round.Eliminated {
+			delete(remaining, option)
+		}
+	}
+
+	return result
+}
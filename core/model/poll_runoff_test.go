@@ -0,0 +1,57 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCalculateInstantRunoff(t *testing.T) {
+	tests := []struct {
+		name           string
+		optionsCount   int
+		ballots        [][]int
+		wantWinner     *int
+		wantRounds     int
+		wantEliminated []int // the options eliminated in the first round
+		wantExhausted  int   // the exhausted ballots in the last round
+	}{
+		{"no votes", 3, nil, nil, 1, []int{}, 0},
+		{"majority in the first round", 3, [][]int{{0}, {0}, {1}}, ptr(0), 1, []int{}, 0},
+		{"single option", 1, [][]int{{0}}, ptr(0), 1, []int{}, 0},
+		{"majority after the elimination", 3, [][]int{{0}, {0}, {1}, {1}, {2, 1}}, ptr(1), 2, []int{2}, 0},
+		{"options without votes are eliminated together", 4, [][]int{{0}, {0, 2}, {1}, {1, 3}}, nil, 2, []int{2, 3}, 0},
+		{"tie", 2, [][]int{{0}, {1}}, nil, 1, []int{0, 1}, 0},
+		{"tie after the exhausted ballots", 3, [][]int{{0}, {0}, {1}, {1}, {2}}, nil, 2, []int{2}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateInstantRunoff(tt.optionsCount, tt.ballots)
+			if (got.Winner == nil) != (tt.wantWinner == nil) || (got.Winner != nil && *got.Winner != *tt.wantWinner) {
+				t.Errorf("CalculateInstantRunoff() winner = %v, want %v", got.Winner, tt.wantWinner)
+			}
+			if len(got.Rounds) != tt.wantRounds {
+				t.Fatalf("CalculateInstantRunoff() rounds = %d, want %d", len(got.Rounds), tt.wantRounds)
+			}
+			if !slices.Equal(got.Rounds[0].Eliminated, tt.wantEliminated) {
+				t.Errorf("CalculateInstantRunoff() eliminated = %v, want %v", got.Rounds[0].Eliminated, tt.wantEliminated)
+			}
+			if last := got.Rounds[len(got.Rounds)-1]; last.Exhausted != tt.wantExhausted {
+				t.Errorf("CalculateInstantRunoff() exhausted = %d, want %d", last.Exhausted, tt.wantExhausted)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// the tolerance used when checking if a value is on the scale steps
+const scaleStepTolerance = 1e-9
+
+// PollScale represents the allowed values of a rating or numeric poll
+type PollScale struct {
+	Min  float64 `json:"min" bson:"min"`
+	Max  float64 `json:"max" bson:"max"`
+	Step float64 `json:"step" bson:"step"`
+} // @name PollScale
+
+// Validate checks if the scale is consistent
+func (s *PollScale) Validate() error {
+	if s.Step <= 0 {
+		return fmt.Errorf("%w: scale step must be positive", ErrInvalidPoll)
+	}
+	if s.Min >= s.Max {
+		return fmt.Errorf("%w: scale min must be less than max", ErrInvalidPoll)
+	}
+	if _, ok := s.StepIndex(s.Max); !ok {
+		return fmt.Errorf("%w: scale max must be reachable from min with the step", ErrInvalidPoll)
+	}
+	return nil
+}
+
+// StepIndex gets the index of the scale step of the value. Returns false if the value is not a step of the scale.
+func (s *PollScale) StepIndex(value float64) (int, bool) {
+	if value < s.Min-scaleStepTolerance || value > s.Max+scaleStepTolerance {
+		return 0, false
+	}
+
+	steps := (value - s.Min) / s.Step
+	index := math.Round(steps)
+	if math.Abs(steps-index) > scaleStepTolerance*math.Max(1, steps) {
+		return 0, false
+	}
+	return int(index), true
+}
+
+// StepValue gets the value of the scale step with the index
+func (s *PollScale) StepValue(index int) float64 {
+	return s.Min + float64(index)*s.Step
+}
+
+// PollStats represents the statistics of the answers of a rating or numeric poll
+type PollStats struct {
+	Count     int                   `json:"count"`
+	Mean      float64               `json:"mean"`
+	Median    float64               `json:"median"`
+	StdDev    float64               `json:"std_dev"` // population standard deviation
+	Histogram []PollHistogramBucket `json:"histogram"`
+} // @name PollStats
+
+// PollHistogramBucket represents the answers count for a scale value
+type PollHistogramBucket struct {
+	Value float64 `json:"value"`
+	Count int     `json:"count"`
+} // @name PollHistogramBucket
+
+// CalculateScaleStats calculates the statistics from the answers count per scale step index
+func CalculateScaleStats(scale PollScale, stepCounts map[string]int) PollStats {
+	stats := PollStats{Histogram: []PollHistogramBucket{}}
+
+	indexes := []int{}
+	counts := map[int]int{}
+	for key, count := range stepCounts {
+		index, err := strconv.Atoi(key)
+		if err != nil || count <= 0 {
+			continue
+		}
+		indexes = append(indexes, index)
+		counts[index] = count
+	}
+	sort.Ints(indexes)
+
+	sum := 0.0
+	for _, index := range indexes {
+		value := scale.StepValue(index)
+		stats.Histogram = append(stats.Histogram, PollHistogramBucket{Value: value, Count: counts[index]})
+		stats.Count += counts[index]
+		sum += value * float64(counts[index])
+	}
+	if stats.Count == 0 {
+		return stats
+	}
+
+	stats.Mean = sum / float64(stats.Count)
+
+	variance := 0.0
+	for _, bucket := range stats.Histogram {
+		diff := bucket.Value - stats.Mean
+		variance += diff * diff * float64(bucket.Count)
+	}
+	stats.StdDev = math.Sqrt(variance / float64(stats.Count))
+
+	//the median is the middle value, or the mean of the two middle values
+	lower := histogramValueAt(stats.Histogram, (stats.Count-1)/2)
+	upper := histogramValueAt(stats.Histogram, stats.Count/2)
+	stats.Median = (lower + upper) / 2
+
+	return stats
+}
+
+// histogramValueAt gets the value at the position of the sorted answers
+func histogramValueAt(histogram []PollHistogramBucket, position int) float64 {
+	for _, bucket := range histogram {
+		if position < bucket.Count {
+			return bucket.Value
+		}
+		position -= bucket.Count
+	}
+	return 0
+}
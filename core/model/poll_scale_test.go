@@ -0,0 +1,124 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPollScaleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		scale   PollScale
+		wantErr bool
+	}{
+		{"rating", PollScale{Min: 1, Max: 5, Step: 1}, false},
+		{"decimal step", PollScale{Min: 0, Max: 1, Step: 0.1}, false},
+		{"negative min", PollScale{Min: -10, Max: 10, Step: 5}, false},
+		{"zero step", PollScale{Min: 1, Max: 5, Step: 0}, true},
+		{"negative step", PollScale{Min: 1, Max: 5, Step: -1}, true},
+		{"min equal to max", PollScale{Min: 5, Max: 5, Step: 1}, true},
+		{"min over max", PollScale{Min: 5, Max: 1, Step: 1}, true},
+		{"max not on a step", PollScale{Min: 0, Max: 10, Step: 3}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.scale.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidPoll) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalidPoll)
+			}
+		})
+	}
+}
+
+func TestPollScaleStepIndex(t *testing.T) {
+	rating := PollScale{Min: 1, Max: 5, Step: 1}
+	decimal := PollScale{Min: 0, Max: 1, Step: 0.1}
+
+	tests := []struct {
+		name   string
+		scale  PollScale
+		value  float64
+		want   int
+		wantOk bool
+	}{
+		{"min", rating, 1, 0, true},
+		{"max", rating, 5, 4, true},
+		{"middle", rating, 3, 2, true},
+		{"between the steps", rating, 2.5, 0, false},
+		{"under the min", rating, 0, 0, false},
+		{"over the max", rating, 6, 0, false},
+		{"decimal step", decimal, 0.3, 3, true},
+		{"decimal max", decimal, 1, 10, true},
+		{"between the decimal steps", decimal, 0.35, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.scale.StepIndex(tt.value)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("StepIndex() = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestCalculateScaleStats(t *testing.T) {
+	rating := PollScale{Min: 1, Max: 5, Step: 1}
+
+	tests := []struct {
+		name       string
+		stepCounts map[string]int
+		want       PollStats
+	}{
+		{"no answers", map[string]int{}, PollStats{}},
+		{"single answer", map[string]int{"2": 1}, PollStats{Count: 1, Mean: 3, Median: 3, StdDev: 0,
+			Histogram: []PollHistogramBucket{{Value: 3, Count: 1}}}},
+		{"odd count", map[string]int{"0": 1, "2": 1, "4": 1}, PollStats{Count: 3, Mean: 3, Median: 3, StdDev: math.Sqrt(8.0 / 3),
+			Histogram: []PollHistogramBucket{{Value: 1, Count: 1}, {Value: 3, Count: 1}, {Value: 5, Count: 1}}}},
+		{"even count", map[string]int{"4": 1, "0": 3}, PollStats{Count: 4, Mean: 2, Median: 1, StdDev: math.Sqrt(3),
+			Histogram: []PollHistogramBucket{{Value: 1, Count: 3}, {Value: 5, Count: 1}}}},
+		{"median between the steps", map[string]int{"1": 1, "2": 1}, PollStats{Count: 2, Mean: 2.5, Median: 2.5, StdDev: 0.5,
+			Histogram: []PollHistogramBucket{{Value: 2, Count: 1}, {Value: 3, Count: 1}}}},
+		{"invalid keys and empty counts", map[string]int{"x": 4, "1": 0, "3": 2}, PollStats{Count: 2, Mean: 4, Median: 4, StdDev: 0,
+			Histogram: []PollHistogramBucket{{Value: 4, Count: 2}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateScaleStats(rating, tt.stepCounts)
+			if got.Count != tt.want.Count || !floatEqual(got.Mean, tt.want.Mean) || !floatEqual(got.Median, tt.want.Median) ||
+				!floatEqual(got.StdDev, tt.want.StdDev) {
+				t.Errorf("CalculateScaleStats() = %+v, want %+v", got, tt.want)
+			}
+			if len(got.Histogram) != len(tt.want.Histogram) {
+				t.Fatalf("CalculateScaleStats() histogram = %v, want %v", got.Histogram, tt.want.Histogram)
+			}
+			for i, bucket := range got.Histogram {
+				if !floatEqual(bucket.Value, tt.want.Histogram[i].Value) || bucket.Count != tt.want.Histogram[i].Count {
+					t.Errorf("CalculateScaleStats() histogram = %v, want %v", got.Histogram, tt.want.Histogram)
+					break
+				}
+			}
+		})
+	}
+}
+
+func floatEqual(a float64, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
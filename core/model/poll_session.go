@@ -0,0 +1,111 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// PollSessionStatusCreated the session is not presented yet
+	PollSessionStatusCreated = "created"
+	// PollSessionStatusLive the session is presented, the current poll is live
+	PollSessionStatusLive = "live"
+	// PollSessionStatusEnded the session has ended
+	PollSessionStatusEnded = "ended"
+)
+
+var (
+	// ErrInvalidPollSession the poll session is invalid
+	ErrInvalidPollSession = errors.New("invalid poll session")
+
+	// ErrPollSessionNotFound the poll session does not exist
+	ErrPollSessionNotFound = errors.New("poll session not found")
+)
+
+// PollSession represents a live presentation of ordered polls. The presenter advances the session to the next poll,
+// which starts it and ends the previous one. The participants join the whole session by its PIN.
+type PollSession struct {
+	ID            string     `json:"id" bson:"_id"`
+	OrgID         string     `json:"org_id" bson:"org_id"`
+	UserID        string     `json:"user_id" bson:"user_id"` // the presenter
+	UserName      string     `json:"user_name" bson:"user_name"`
+	Title         string     `json:"title" bson:"title"`
+	PollIDs       []string   `json:"poll_ids" bson:"poll_ids"`                                   // the polls in the presentation order
+	CurrentPollID string     `json:"current_poll_id,omitempty" bson:"current_poll_id,omitempty"` // the live poll
+	Pin           int        `json:"pin,omitempty" bson:"pin"`
+	AutoPin       bool       `json:"auto_pin,omitempty" bson:"-"`   // the server allocates a PIN which is not used by another active session
+	ActivePin     *int       `json:"-" bson:"active_pin,omitempty"` // the PIN reserved while the session has not ended
+	Status        string     `json:"status" bson:"status"`          // created, live or ended
+	DateCreated   time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated   *time.Time `json:"date_updated" bson:"date_updated"`
+} // @name PollSession
+
+// Validate checks if the session title, polls and PIN are valid
+func (s *PollSession) Validate() error {
+	if len(strings.TrimSpace(s.Title)) == 0 {
+		return fmt.Errorf("%w: title is required", ErrInvalidPollSession)
+	}
+	if len(s.PollIDs) == 0 {
+		return fmt.Errorf("%w: at least one poll is required", ErrInvalidPollSession)
+	}
+	for i, pollID := range s.PollIDs {
+		if len(pollID) == 0 {
+			return fmt.Errorf("%w: empty poll id", ErrInvalidPollSession)
+		}
+		if slices.Contains(s.PollIDs[:i], pollID) {
+			return fmt.Errorf("%w: poll %s is added more than once", ErrInvalidPollSession, pollID)
+		}
+	}
+	if s.Pin < 0 || s.Pin > MaxPollPin {
+		return fmt.Errorf("%w: pin must be between 0 and %d", ErrInvalidPollSession, MaxPollPin)
+	}
+	return nil
+}
+
+// NextPollID gets the poll after the current poll, or an empty string if the current poll is the last one
+func (s *PollSession) NextPollID() string {
+	next := slices.Index(s.PollIDs, s.CurrentPollID) + 1
+	if next < len(s.PollIDs) {
+		return s.PollIDs[next]
+	}
+	return ""
+}
+
+// Advance gets the status and the current poll of the session advanced to the next poll. The session ends when it is
+// advanced past the last poll.
+func (s *PollSession) Advance() (string, string, error) {
+	if s.Status == PollSessionStatusEnded {
+		return "", "", fmt.Errorf("%w: the session has ended", ErrInvalidPollTransition)
+	}
+
+	nextPollID := s.NextPollID()
+	if len(nextPollID) == 0 {
+		return PollSessionStatusEnded, "", nil
+	}
+	return PollSessionStatusLive, nextPollID, nil
+}
+
+// End gets the status and the current poll of the ended session
+func (s *PollSession) End() (string, string, error) {
+	if s.Status == PollSessionStatusEnded {
+		return "", "", fmt.Errorf("%w: the session has ended", ErrInvalidPollTransition)
+	}
+	return PollSessionStatusEnded, "", nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPollSessionValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		session PollSession
+		wantErr bool
+	}{
+		{"single poll", PollSession{Title: "Lecture", PollIDs: []string{"1"}}, false},
+		{"many polls with a pin", PollSession{Title: "Lecture", PollIDs: []string{"1", "2", "3"}, Pin: MaxPollPin}, false},
+		{"empty title", PollSession{Title: " ", PollIDs: []string{"1"}}, true},
+		{"no polls", PollSession{Title: "Lecture"}, true},
+		{"empty poll id", PollSession{Title: "Lecture", PollIDs: []string{"1", ""}}, true},
+		{"duplicate poll", PollSession{Title: "Lecture", PollIDs: []string{"1", "2", "1"}}, true},
+		{"negative pin", PollSession{Title: "Lecture", PollIDs: []string{"1"}, Pin: -1}, true},
+		{"pin over the max", PollSession{Title: "Lecture", PollIDs: []string{"1"}, Pin: MaxPollPin + 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.session.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidPollSession) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalidPollSession)
+			}
+		})
+	}
+}
+
+func TestPollSessionAdvance(t *testing.T) {
+	pollIDs := []string{"1", "2", "3"}
+
+	tests := []struct {
+		name        string
+		status      string
+		currentPoll string
+		wantStatus  string
+		wantPoll    string
+		wantErr     bool
+	}{
+		{"start a created session", PollSessionStatusCreated, "", PollSessionStatusLive, "1", false},
+		{"advance a live session", PollSessionStatusLive, "1", PollSessionStatusLive, "2", false},
+		{"advance to the last poll", PollSessionStatusLive, "2", PollSessionStatusLive, "3", false},
+		{"advance past the last poll", PollSessionStatusLive, "3", PollSessionStatusEnded, "", false},
+		{"advance an ended session", PollSessionStatusEnded, "", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := PollSession{PollIDs: pollIDs, Status: tt.status, CurrentPollID: tt.currentPoll}
+			status, currentPollID, err := session.Advance()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Advance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidPollTransition) {
+				t.Errorf("Advance() error = %v, want %v", err, ErrInvalidPollTransition)
+			}
+			if status != tt.wantStatus || currentPollID != tt.wantPoll {
+				t.Errorf("Advance() = %s %q, want %s %q", status, currentPollID, tt.wantStatus, tt.wantPoll)
+			}
+		})
+	}
+}
+
+func TestPollSessionEnd(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      string
+		currentPoll string
+		wantErr     bool
+	}{
+		{"end a created session", PollSessionStatusCreated, "", false},
+		{"end a live session", PollSessionStatusLive, "2", false},
+		{"end an ended session", PollSessionStatusEnded, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := PollSession{PollIDs: []string{"1", "2", "3"}, Status: tt.status, CurrentPollID: tt.currentPoll}
+			status, currentPollID, err := session.End()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("End() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidPollTransition) {
+					t.Errorf("End() error = %v, want %v", err, ErrInvalidPollTransition)
+				}
+				return
+			}
+			if status != PollSessionStatusEnded || len(currentPollID) > 0 {
+				t.Errorf("End() = %s %q, want %s without a current poll", status, currentPollID, PollSessionStatusEnded)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// PollStatusCreated the poll is created and does not accept votes yet
+	PollStatusCreated = "created"
+	// PollStatusStarted the poll accepts votes
+	PollStatusStarted = "started"
+	// PollStatusPaused the poll does not accept votes until it is started again
+	PollStatusPaused = "paused"
+	// PollStatusTerminated the poll has ended. It accepts votes again only if it is reopened.
+	PollStatusTerminated = "terminated"
+
+	// PollActionStart starts a created poll or resumes a paused poll
+	PollActionStart = "start"
+	// PollActionPause pauses a started poll
+	PollActionPause = "pause"
+	// PollActionEnd ends a poll which is not terminated
+	PollActionEnd = "end"
+	// PollActionReopen starts a terminated poll again
+	PollActionReopen = "reopen"
+
+	// PollTransitionReasonSchedule the transition is triggered by the poll start or end time
+	PollTransitionReasonSchedule = "schedule"
+	// PollTransitionReasonMaxVoters the poll has ended because the max number of unique voters is reached
+	PollTransitionReasonMaxVoters = "max_voters"
+	// PollTransitionReasonOptionVotes the poll has ended because an option has reached the votes threshold
+	PollTransitionReasonOptionVotes = "option_votes"
+)
+
+// the poll status reached by every allowed action per status
+var pollStatusTransitions = map[string]map[string]string{
+	PollStatusCreated: {
+		PollActionStart: PollStatusStarted,
+		PollActionEnd:   PollStatusTerminated,
+	},
+	PollStatusStarted: {
+		PollActionPause: PollStatusPaused,
+		PollActionEnd:   PollStatusTerminated,
+	},
+	PollStatusPaused: {
+		PollActionStart: PollStatusStarted,
+		PollActionEnd:   PollStatusTerminated,
+	},
+	PollStatusTerminated: {
+		PollActionReopen: PollStatusStarted,
+	},
+}
+
+// ErrInvalidPollTransition the action is not allowed in the current poll status
+var ErrInvalidPollTransition = errors.New("invalid poll status transition")
+
+// PollStatusTransition represents a change of the poll status
+type PollStatusTransition struct {
+	Action     string    `json:"action" bson:"action"` // start, pause, end or reopen
+	FromStatus string    `json:"from_status" bson:"from_status"`
+	ToStatus   string    `json:"to_status" bson:"to_status"`
+	ActorID    string    `json:"actor_id,omitempty" bson:"actor_id,omitempty"` // empty when the transition is triggered by the system, e.g. the scheduler
+	ActorName  string    `json:"actor_name,omitempty" bson:"actor_name,omitempty"`
+	Reason     string    `json:"reason,omitempty" bson:"reason,omitempty"` // why the system triggered the transition: schedule, max_voters or option_votes
+	Date       time.Time `json:"date" bson:"date"`
+} // @name PollStatusTransition
+
+// NewPollStatusTransition creates the transition of the action from the status. The user is nil for the system triggered transitions.
+func NewPollStatusTransition(user *User, status string, action string) (*PollStatusTransition, error) {
+	toStatus, ok := pollStatusTransitions[status][action]
+	if !ok {
+		return nil, fmt.Errorf("%w: can not %s a %s poll", ErrInvalidPollTransition, action, status)
+	}
+
+	transition := PollStatusTransition{Action: action, FromStatus: status, ToStatus: toStatus, Date: time.Now().UTC()}
+	if user != nil {
+		transition.ActorID = user.Claims.Subject
+		transition.ActorName = user.Claims.Name
+	}
+	return &transition, nil
+}
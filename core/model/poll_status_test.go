@@ -0,0 +1,80 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewPollStatusTransition(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		action     string
+		wantStatus string
+		wantErr    bool
+	}{
+		{"start a created poll", PollStatusCreated, PollActionStart, PollStatusStarted, false},
+		{"end a created poll", PollStatusCreated, PollActionEnd, PollStatusTerminated, false},
+		{"pause a created poll", PollStatusCreated, PollActionPause, "", true},
+		{"reopen a created poll", PollStatusCreated, PollActionReopen, "", true},
+		{"pause a started poll", PollStatusStarted, PollActionPause, PollStatusPaused, false},
+		{"end a started poll", PollStatusStarted, PollActionEnd, PollStatusTerminated, false},
+		{"start a started poll", PollStatusStarted, PollActionStart, "", true},
+		{"resume a paused poll", PollStatusPaused, PollActionStart, PollStatusStarted, false},
+		{"end a paused poll", PollStatusPaused, PollActionEnd, PollStatusTerminated, false},
+		{"pause a paused poll", PollStatusPaused, PollActionPause, "", true},
+		{"reopen a terminated poll", PollStatusTerminated, PollActionReopen, PollStatusStarted, false},
+		{"start a terminated poll", PollStatusTerminated, PollActionStart, "", true},
+		{"end a terminated poll", PollStatusTerminated, PollActionEnd, "", true},
+		{"unknown action", PollStatusStarted, "stop", "", true},
+		{"unknown status", "archived", PollActionStart, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPollStatusTransition(nil, tt.status, tt.action)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPollStatusTransition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidPollTransition) {
+					t.Errorf("NewPollStatusTransition() error = %v, want %v", err, ErrInvalidPollTransition)
+				}
+				return
+			}
+			if got.FromStatus != tt.status || got.ToStatus != tt.wantStatus || got.Action != tt.action {
+				t.Errorf("NewPollStatusTransition() = %s %s -> %s, want %s %s -> %s", got.Action, got.FromStatus, got.ToStatus, tt.action, tt.status, tt.wantStatus)
+			}
+			if len(got.ActorID) > 0 {
+				t.Errorf("NewPollStatusTransition() actor = %s, want the system", got.ActorID)
+			}
+		})
+	}
+}
+
+func TestNewPollStatusTransitionActor(t *testing.T) {
+	user := User{}
+	user.Claims.Subject = "user-1"
+	user.Claims.Name = "Jane Doe"
+
+	got, err := NewPollStatusTransition(&user, PollStatusCreated, PollActionStart)
+	if err != nil {
+		t.Fatalf("NewPollStatusTransition() error = %v", err)
+	}
+	if got.ActorID != "user-1" || got.ActorName != "Jane Doe" {
+		t.Errorf("NewPollStatusTransition() actor = %s (%s), want user-1 (Jane Doe)", got.ActorID, got.ActorName)
+	}
+}
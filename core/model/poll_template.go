@@ -0,0 +1,131 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidPollTemplate the poll template is invalid
+	ErrInvalidPollTemplate = errors.New("invalid poll template")
+
+	// ErrPollTemplateNotFound the poll template does not exist
+	ErrPollTemplateNotFound = errors.New("poll template not found")
+)
+
+// PollTemplate represents a saved poll question with its options and settings. A template without a group is personal,
+// a group template is available to the group members.
+type PollTemplate struct {
+	ID          string     `json:"id" bson:"_id"`
+	OrgID       string     `json:"org_id" bson:"org_id"`
+	UserID      string     `json:"user_id" bson:"user_id"` // the creator of the template
+	GroupID     *string    `json:"group_id,omitempty" bson:"group_id,omitempty"`
+	Name        string     `json:"name" bson:"name"`
+	Poll        PollData   `json:"poll" bson:"poll"` // the poll content, see PollData.CopyContent
+	DateCreated time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated *time.Time `json:"date_updated" bson:"date_updated"`
+} // @name PollTemplate
+
+// Validate checks if the template name and poll content are valid
+func (t *PollTemplate) Validate() error {
+	if len(strings.TrimSpace(t.Name)) == 0 {
+		return fmt.Errorf("%w: name is required", ErrInvalidPollTemplate)
+	}
+	if len(strings.TrimSpace(t.Poll.Question)) == 0 {
+		return fmt.Errorf("%w: question is required", ErrInvalidPollTemplate)
+	}
+	err := t.Poll.Validate()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidPollTemplate, err)
+	}
+	return nil
+}
+
+// HideQuizAnswers removes the correct options of a quiz template, so the users who take the quiz can not see them
+func (t *PollTemplate) HideQuizAnswers() {
+	if t.Poll.Quiz != nil {
+		t.Poll.Quiz = t.Poll.Quiz.copy(true)
+	}
+}
+
+// PollCloneRequest represents the options for cloning a poll
+type PollCloneRequest struct {
+	GroupID *string `json:"group_id,omitempty"` // the group of the new poll, the group of the cloned poll if nil, no group if empty
+} // @name PollCloneRequest
+
+// CopyContent copies the question, the options and the settings of the poll. The owners, the members, the group,
+// the PIN, the status, the schedule, the session and the recurrence belong to a single poll, so they are not copied.
+func (pd *PollData) CopyContent() PollData {
+	content := PollData{
+		Question:          pd.Question,
+		Options:           append([]string{}, pd.Options...),
+		MultiChoice:       pd.MultiChoice,
+		Repeat:            pd.Repeat,
+		AllowVoteChange:   pd.AllowVoteChange,
+		ShowResults:       pd.ShowResults,
+		ResultsVisibility: pd.ResultsVisibility,
+		Stadium:           pd.Stadium,
+		Geo:               pd.Geo,
+		Anonymous:         pd.Anonymous,
+		PollType:          pd.PollType,
+		DefaultLocale:     pd.DefaultLocale,
+	}
+	if len(pd.Localizations) > 0 {
+		content.Localizations = make(map[string]PollLocalization, len(pd.Localizations))
+		for locale, localization := range pd.Localizations {
+			content.Localizations[locale] = PollLocalization{Question: localization.Question, Options: append([]string{}, localization.Options...)}
+		}
+	}
+	if pd.GeoRegion != nil {
+		region := *pd.GeoRegion
+		content.GeoRegion = &region
+	}
+	if pd.Scale != nil {
+		scale := *pd.Scale
+		content.Scale = &scale
+	}
+	if pd.AutoClose != nil {
+		autoClose := *pd.AutoClose
+		content.AutoClose = &autoClose
+	}
+	if pd.Quiz != nil {
+		content.Quiz = pd.Quiz.copy(false)
+	}
+	return content
+}
+
+// NewInstance creates the data of a new poll with the content of the poll and the single poll fields of the instance
+func (pd *PollData) NewInstance(instance PollData) PollData {
+	poll := pd.CopyContent()
+	poll.UserID = instance.UserID
+	poll.UserName = instance.UserName
+	poll.CoOwners = instance.CoOwners
+	poll.ToMembersList = instance.ToMembersList
+	poll.GroupID = instance.GroupID
+	poll.Pin = instance.Pin
+	poll.AutoPin = instance.AutoPin
+	poll.Status = instance.Status
+	poll.StartAt = instance.StartAt
+	poll.EndAt = instance.EndAt
+	poll.TemplateID = instance.TemplateID
+	poll.SessionID = instance.SessionID
+	poll.SeriesID = instance.SeriesID
+	poll.Recurrence = instance.Recurrence
+	return poll
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// testSourcePoll gets a poll with both the content and the single poll fields set
+func testSourcePoll() PollData {
+	now := time.Now().UTC()
+	end := now.Add(time.Hour)
+	return PollData{
+		UserID:            "owner",
+		UserName:          "Owner",
+		ToMembersList:     ToMembers{{UserID: "member"}},
+		Question:          "Favorite color?",
+		Options:           []string{"red", "blue"},
+		GroupID:           ptr("group"),
+		Pin:               1234,
+		AutoPin:           true,
+		MultiChoice:       true,
+		AllowVoteChange:   true,
+		ShowResults:       true,
+		ResultsVisibility: ResultsVisibilityAfterVote,
+		Stadium:           "memorial",
+		Geo:               true,
+		GeoRegion:         &PollGeoRegion{Center: &testGeoCenter, Radius: 100},
+		Status:            PollStatusStarted,
+		StartAt:           &now,
+		EndAt:             &end,
+		AutoClose:         &PollAutoClose{MaxVoters: 10},
+		TemplateID:        "template",
+		Quiz:              &PollQuiz{CorrectOptions: []int{1}, Points: 2},
+		SessionID:         "session",
+		DefaultLocale:     "en",
+		Localizations:     map[string]PollLocalization{"es": {Question: "¿Color favorito?", Options: []string{"rojo", "azul"}}},
+		Recurrence:        &PollRecurrence{},
+		SeriesID:          "series",
+		CoOwners:          []string{"co-owner"},
+		DateCreated:       now,
+		DateUpdated:       now,
+	}
+}
+
+func TestPollDataCopyContent(t *testing.T) {
+	poll := testSourcePoll()
+	got := poll.CopyContent()
+
+	//the content is kept
+	if got.Question != poll.Question || !slices.Equal(got.Options, poll.Options) || got.MultiChoice != poll.MultiChoice ||
+		got.AllowVoteChange != poll.AllowVoteChange || got.ShowResults != poll.ShowResults || got.ResultsVisibility != poll.ResultsVisibility ||
+		got.Stadium != poll.Stadium || got.Geo != poll.Geo || got.DefaultLocale != poll.DefaultLocale {
+		t.Errorf("CopyContent() = %+v, want the content of %+v", got, poll)
+	}
+	if got.GeoRegion == nil || got.GeoRegion.Radius != poll.GeoRegion.Radius || got.AutoClose == nil || got.AutoClose.MaxVoters != 10 ||
+		got.Quiz == nil || !slices.Equal(got.Quiz.CorrectOptions, poll.Quiz.CorrectOptions) || got.Quiz.Points != poll.Quiz.Points ||
+		got.Localizations["es"].Question != poll.Localizations["es"].Question {
+		t.Errorf("CopyContent() = %+v, want the settings of %+v", got, poll)
+	}
+
+	//the single poll fields are reset
+	if len(got.UserID) > 0 || len(got.UserName) > 0 || got.ToMembersList != nil || got.GroupID != nil || got.Pin != 0 || got.AutoPin ||
+		len(got.Status) > 0 || got.StartAt != nil || got.EndAt != nil || len(got.TemplateID) > 0 || len(got.SessionID) > 0 ||
+		got.Recurrence != nil || len(got.SeriesID) > 0 || got.CoOwners != nil || !got.DateCreated.IsZero() || !got.DateUpdated.IsZero() {
+		t.Errorf("CopyContent() = %+v, want the single poll fields reset", got)
+	}
+
+	//the copy does not share the references
+	got.Options[0] = "green"
+	got.GeoRegion.Radius = 200
+	got.AutoClose.MaxVoters = 20
+	got.Quiz.CorrectOptions[0] = 0
+	got.Localizations["es"].Options[0] = "verde"
+	if poll.Options[0] != "red" || poll.GeoRegion.Radius != 100 || poll.AutoClose.MaxVoters != 10 || poll.Quiz.CorrectOptions[0] != 1 ||
+		poll.Localizations["es"].Options[0] != "rojo" {
+		t.Errorf("CopyContent() shares the references with %+v", poll)
+	}
+}
+
+func TestPollDataNewInstance(t *testing.T) {
+	poll := testSourcePoll()
+	start := time.Now().UTC().Add(time.Hour)
+	instance := PollData{UserID: "instance owner", UserName: "Instance Owner", Status: PollStatusCreated, StartAt: &start,
+		TemplateID: "instance template", SeriesID: "instance series"}
+
+	got := poll.NewInstance(instance)
+	if got.Question != poll.Question || !slices.Equal(got.Options, poll.Options) || got.Quiz == nil || got.GeoRegion == nil {
+		t.Errorf("NewInstance() = %+v, want the content of %+v", got, poll)
+	}
+	if got.UserID != instance.UserID || got.UserName != instance.UserName || got.Status != PollStatusCreated || got.StartAt != &start ||
+		got.TemplateID != instance.TemplateID || got.SeriesID != instance.SeriesID {
+		t.Errorf("NewInstance() = %+v, want the single poll fields of %+v", got, instance)
+	}
+	if got.Pin != 0 || got.GroupID != nil || got.EndAt != nil || len(got.SessionID) > 0 || got.Recurrence != nil || got.CoOwners != nil ||
+		!got.DateCreated.IsZero() {
+		t.Errorf("NewInstance() = %+v, want the single poll fields of the poll reset", got)
+	}
+}
+
+func TestPollTemplateHideQuizAnswers(t *testing.T) {
+	poll := testSourcePoll()
+	template := PollTemplate{Poll: poll.CopyContent()}
+	template.HideQuizAnswers()
+
+	if template.Poll.Quiz == nil || len(template.Poll.Quiz.CorrectOptions) > 0 || template.Poll.Quiz.Points != poll.Quiz.Points {
+		t.Errorf("HideQuizAnswers() quiz = %+v, want the points only", template.Poll.Quiz)
+	}
+	if len(poll.Quiz.CorrectOptions) != 1 {
+		t.Errorf("HideQuizAnswers() changed the quiz of the source poll")
+	}
+
+	template = PollTemplate{}
+	template.HideQuizAnswers()
+	if template.Poll.Quiz != nil {
+		t.Errorf("HideQuizAnswers() quiz = %+v, want nil", template.Poll.Quiz)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPollDataValidateVote(t *testing.T) {
+	options := []string{"red", "green", "blue"}
+	choice := PollData{Options: options}
+	multiChoice := PollData{Options: options, MultiChoice: true}
+	ranked := PollData{Options: options, PollType: PollTypeRanked}
+	rating := PollData{PollType: PollTypeRating, Scale: &PollScale{Min: 1, Max: 5, Step: 1}}
+	openText := PollData{PollType: PollTypeOpenText}
+
+	tests := []struct {
+		name    string
+		poll    PollData
+		vote    PollVote
+		wantErr bool
+	}{
+		{"single answer", choice, PollVote{Answer: []int{1}}, false},
+		{"empty answer", choice, PollVote{}, true},
+		{"many answers of a single choice", choice, PollVote{Answer: []int{0, 1}}, true},
+		{"negative option", choice, PollVote{Answer: []int{-1}}, true},
+		{"option out of range", choice, PollVote{Answer: []int{3}}, true},
+		{"text of a choice", choice, PollVote{Answer: []int{0}, Text: "red"}, true},
+		{"many answers", multiChoice, PollVote{Answer: []int{0, 2}}, false},
+		{"duplicate answers", multiChoice, PollVote{Answer: []int{2, 2}}, true},
+		{"ranking", ranked, PollVote{Answer: []int{2, 0, 1}}, false},
+		{"partial ranking", ranked, PollVote{Answer: []int{1}}, false},
+		{"duplicate ranking", ranked, PollVote{Answer: []int{1, 1}}, true},
+		{"scale value", rating, PollVote{Value: ptr(4.0)}, false},
+		{"empty value", rating, PollVote{}, true},
+		{"value off the scale", rating, PollVote{Value: ptr(2.5)}, true},
+		{"value over the max", rating, PollVote{Value: ptr(6.0)}, true},
+		{"answer with a value", rating, PollVote{Answer: []int{0}, Value: ptr(4.0)}, true},
+		{"missing scale", PollData{PollType: PollTypeNumeric}, PollVote{Value: ptr(1.0)}, true},
+		{"text", openText, PollVote{Text: "blue"}, false},
+		{"blank text", openText, PollVote{Text: "  "}, true},
+		{"text too long", openText, PollVote{Text: strings.Repeat("a", MaxTextAnswerLength+1)}, true},
+		{"answer of an open text", openText, PollVote{Answer: []int{0}, Text: "blue"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.poll.ValidateVote(tt.vote)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateVote() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidVote) {
+				t.Errorf("ValidateVote() error = %v, want %v", err, ErrInvalidVote)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// TextAnswerStatusPending the text answer waits for moderation
+	TextAnswerStatusPending = "pending"
+	// TextAnswerStatusApproved the text answer appears in the results
+	TextAnswerStatusApproved = "approved"
+	// TextAnswerStatusHidden the text answer never appears in the results
+	TextAnswerStatusHidden = "hidden"
+
+	// MaxTextAnswerLength the max number of characters of a text answer
+	MaxTextAnswerLength = 280
+
+	// the max number of approved answers in the results, the pinned answers are always included
+	maxResultTextAnswers = 100
+	// the max number of words in the word frequencies
+	maxWordFrequencies = 50
+	// the shorter words are not counted in the word frequencies
+	minWordLength = 3
+)
+
+// common english words skipped by the word frequencies
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "but": true, "not": true, "you": true, "all": true,
+	"any": true, "can": true, "had": true, "her": true, "was": true, "one": true, "our": true, "out": true,
+	"has": true, "him": true, "his": true, "how": true, "its": true, "who": true, "did": true, "get": true,
+	"she": true, "they": true, "them": true, "this": true, "that": true, "with": true, "have": true, "from": true,
+	"what": true, "were": true, "when": true, "your": true, "there": true, "their": true, "would": true, "about": true,
+	"which": true, "will": true, "been": true, "than": true, "then": true, "also": true, "just": true, "very": true,
+}
+
+// PollTextAnswer represents a text answer of an open text poll
+type PollTextAnswer struct {
+	ID      string    `json:"id" bson:"id"`
+	UserID  string    `json:"user_id,omitempty" bson:"user_id,omitempty"` // empty in the results and for the anonymous polls
+	Text    string    `json:"text" bson:"text"`
+	Status  string    `json:"status,omitempty" bson:"status,omitempty"` // empty in the results
+	Pinned  bool      `json:"pinned" bson:"pinned"`
+	Created time.Time `json:"created" bson:"created"`
+} // @name PollTextAnswer
+
+// PollTextModeration represents a moderation decision for a text answer
+type PollTextModeration struct {
+	Status *string `json:"status,omitempty"` // approved or hidden
+	Pinned *bool   `json:"pinned,omitempty"`
+} // @name PollTextModeration
+
+// WordFrequency represents how many times a word is used in the approved text answers
+type WordFrequency struct {
+	Word  string `json:"word" bson:"word"`
+	Count int    `json:"count" bson:"count"`
+} // @name WordFrequency
+
+// PollTextResults represents the approved answers of an open text poll
+type PollTextResults struct {
+	Answers         []PollTextAnswer `json:"answers" bson:"answers"` // pinned first, then the newest
+	WordFrequencies []WordFrequency  `json:"word_frequencies" bson:"word_frequencies"`
+	Total           int              `json:"total" bson:"total"` // the number of approved answers
+	Revision        int              `json:"-" bson:"revision"`  // the counters revision the results are calculated for
+} // @name PollTextResults
+
+// CalculateTextResults calculates the results from the approved text answers
+func CalculateTextResults(answers []PollTextAnswer) PollTextResults {
+	approved := []PollTextAnswer{}
+	words := map[string]int{}
+	for _, answer := range answers {
+		if answer.Status != TextAnswerStatusApproved {
+			continue
+		}
+		approved = append(approved, PollTextAnswer{ID: answer.ID, Text: answer.Text, Pinned: answer.Pinned, Created: answer.Created})
+
+		for _, word := range strings.FieldsFunc(strings.ToLower(answer.Text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if len([]rune(word)) >= minWordLength && !stopWords[word] {
+				words[word]++
+			}
+		}
+	}
+
+	sort.SliceStable(approved, func(i, j int) bool {
+		if approved[i].Pinned != approved[j].Pinned {
+			return approved[i].Pinned
+		}
+		return approved[i].Created.After(approved[j].Created)
+	})
+
+	results := PollTextResults{Answers: []PollTextAnswer{}, WordFrequencies: []WordFrequency{}, Total: len(approved)}
+	for _, answer := range approved {
+		if answer.Pinned || len(results.Answers) < maxResultTextAnswers {
+			results.Answers = append(results.Answers, answer)
+		}
+	}
+
+	for word, count := range words {
+		results.WordFrequencies = append(results.WordFrequencies, WordFrequency{Word: word, Count: count})
+	}
+	sort.Slice(results.WordFrequencies, func(i, j int) bool {
+		if results.WordFrequencies[i].Count != results.WordFrequencies[j].Count {
+			return results.WordFrequencies[i].Count > results.WordFrequencies[j].Count
+		}
+		return results.WordFrequencies[i].Word < results.WordFrequencies[j].Word
+	})
+	if len(results.WordFrequencies) > maxWordFrequencies {
+		results.WordFrequencies = results.WordFrequencies[:maxWordFrequencies]
+	}
+
+	return results
+}
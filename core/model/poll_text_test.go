@@ -0,0 +1,93 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCalculateTextResults(t *testing.T) {
+	now := time.Now().UTC()
+	approved := func(id string, text string, pinned bool, age time.Duration) PollTextAnswer {
+		return PollTextAnswer{ID: id, UserID: "user-" + id, Text: text, Status: TextAnswerStatusApproved, Pinned: pinned, Created: now.Add(-age)}
+	}
+
+	tests := []struct {
+		name      string
+		answers   []PollTextAnswer
+		wantIDs   []string // the result answers, in order
+		wantTotal int
+		wantWords []WordFrequency
+	}{
+		{"no answers", nil, []string{}, 0, []WordFrequency{}},
+		{"words are normalized and grouped", []PollTextAnswer{
+			approved("1", "Great pizza!", false, time.Minute),
+			approved("2", "great PIZZA", false, 2*time.Minute),
+			approved("3", "pizza,pizza", false, 3*time.Minute),
+		}, []string{"1", "2", "3"}, 3, []WordFrequency{{Word: "pizza", Count: 4}, {Word: "great", Count: 2}}},
+		{"short and stop words are skipped", []PollTextAnswer{
+			approved("1", "It is the best of all", false, time.Minute),
+		}, []string{"1"}, 1, []WordFrequency{{Word: "best", Count: 1}}},
+		{"same counts are sorted by the word", []PollTextAnswer{
+			approved("1", "zebra apple", false, time.Minute),
+		}, []string{"1"}, 1, []WordFrequency{{Word: "apple", Count: 1}, {Word: "zebra", Count: 1}}},
+		{"moderated answers", []PollTextAnswer{
+			approved("1", "approved", false, time.Minute),
+			{ID: "2", Text: "pending", Status: TextAnswerStatusPending, Created: now},
+			{ID: "3", Text: "hidden", Status: TextAnswerStatusHidden, Pinned: true, Created: now},
+		}, []string{"1"}, 1, []WordFrequency{{Word: "approved", Count: 1}}},
+		{"pinned first, then the newest", []PollTextAnswer{
+			approved("old", "one", false, 3*time.Minute),
+			approved("new", "two", false, time.Minute),
+			approved("pinned", "three", true, 5*time.Minute),
+		}, []string{"pinned", "new", "old"}, 3, []WordFrequency{{Word: "three", Count: 1}, {Word: "two", Count: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateTextResults(tt.answers)
+
+			ids := []string{}
+			for _, answer := range got.Answers {
+				ids = append(ids, answer.ID)
+				if len(answer.UserID) > 0 || len(answer.Status) > 0 {
+					t.Errorf("CalculateTextResults() answer %s user = %q, status = %q, want them empty", answer.ID, answer.UserID, answer.Status)
+				}
+			}
+			if !slices.Equal(ids, tt.wantIDs) || got.Total != tt.wantTotal {
+				t.Errorf("CalculateTextResults() answers = %v (%d), want %v (%d)", ids, got.Total, tt.wantIDs, tt.wantTotal)
+			}
+			if !slices.Equal(got.WordFrequencies, tt.wantWords) {
+				t.Errorf("CalculateTextResults() words = %v, want %v", got.WordFrequencies, tt.wantWords)
+			}
+		})
+	}
+}
+
+func TestCalculateTextResultsLimit(t *testing.T) {
+	now := time.Now().UTC()
+	answers := []PollTextAnswer{}
+	for i := 0; i < maxResultTextAnswers+10; i++ {
+		answers = append(answers, PollTextAnswer{Text: "answer", Status: TextAnswerStatusApproved, Created: now.Add(time.Duration(i) * time.Second)})
+	}
+	answers[0].Pinned = true // the oldest one
+
+	got := CalculateTextResults(answers)
+	if got.Total != len(answers) || len(got.Answers) != maxResultTextAnswers || !got.Answers[0].Pinned {
+		t.Errorf("CalculateTextResults() answers = %d of %d, want %d of %d with the pinned one first", len(got.Answers), got.Total,
+			maxResultTextAnswers, len(answers))
+	}
+}
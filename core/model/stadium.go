@@ -0,0 +1,87 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidStadium the stadium definition is invalid
+	ErrInvalidStadium = errors.New("invalid stadium")
+
+	// ErrStadiumNotFound the stadium does not exist
+	ErrStadiumNotFound = errors.New("stadium not found")
+
+	// ErrStadiumExists another stadium of the organization has the same key
+	ErrStadiumExists = errors.New("stadium already exists")
+)
+
+// Stadium represents a stadium definition stored in the poll settings. The polls refer to it by its key.
+type Stadium struct {
+	ID           string              `json:"id" bson:"_id"`
+	OrgID        string              `json:"org_id" bson:"org_id"`
+	Stadium      string              `json:"stadium" bson:"stadium"` // the key used by PollData.Stadium
+	Name         string              `json:"name" bson:"name"`
+	GeoRegion    *PollGeoRegion      `json:"geo_region,omitempty" bson:"geo_region,omitempty"` // the stadium boundary
+	PollDefaults StadiumPollDefaults `json:"poll_defaults" bson:"poll_defaults"`
+	DateCreated  time.Time           `json:"date_created" bson:"date_created"`
+	DateUpdated  *time.Time          `json:"date_updated" bson:"date_updated"`
+} // @name Stadium
+
+// StadiumPollDefaults represents the settings turned on for the polls created for a stadium
+type StadiumPollDefaults struct {
+	ShowResults bool `json:"show_results" bson:"show_results"`
+	Geo         bool `json:"geo_fence" bson:"geo_fence"` // the polls are fenced by the stadium boundary
+	Anonymous   bool `json:"anonymous" bson:"anonymous"`
+	Repeat      bool `json:"repeat" bson:"repeat"`
+} // @name StadiumPollDefaults
+
+// Validate checks if the stadium definition is consistent
+func (s *Stadium) Validate() error {
+	if len(strings.TrimSpace(s.Stadium)) == 0 {
+		return fmt.Errorf("%w: stadium key is required", ErrInvalidStadium)
+	}
+	if len(strings.TrimSpace(s.Name)) == 0 {
+		return fmt.Errorf("%w: name is required", ErrInvalidStadium)
+	}
+	if s.GeoRegion != nil {
+		err := s.GeoRegion.Validate()
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidStadium, err)
+		}
+	}
+	if s.PollDefaults.Geo && s.GeoRegion == nil {
+		return fmt.Errorf("%w: geo region is required for geo-fenced polls", ErrInvalidStadium)
+	}
+	return nil
+}
+
+// ApplyPollDefaults turns on the default settings of the stadium for the poll. The settings already turned on
+// by the poll are kept, and a geo-fenced poll without its own region is fenced by the stadium boundary.
+func (s *Stadium) ApplyPollDefaults(poll *PollData) {
+	poll.ShowResults = poll.ShowResults || s.PollDefaults.ShowResults
+	poll.Geo = poll.Geo || s.PollDefaults.Geo
+	poll.Anonymous = poll.Anonymous || s.PollDefaults.Anonymous
+	poll.Repeat = poll.Repeat || s.PollDefaults.Repeat
+
+	if poll.Geo && poll.GeoRegion == nil && s.GeoRegion != nil {
+		region := *s.GeoRegion
+		poll.GeoRegion = &region
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStadiumValidate(t *testing.T) {
+	circle := &PollGeoRegion{Center: &testGeoCenter, Radius: 300}
+
+	tests := []struct {
+		name    string
+		stadium Stadium
+		wantErr bool
+	}{
+		{"without region", Stadium{Stadium: "memorial", Name: "Memorial Stadium"}, false},
+		{"geo-fenced polls", Stadium{Stadium: "memorial", Name: "Memorial Stadium", GeoRegion: circle, PollDefaults: StadiumPollDefaults{Geo: true}}, false},
+		{"empty key", Stadium{Stadium: " ", Name: "Memorial Stadium"}, true},
+		{"empty name", Stadium{Stadium: "memorial"}, true},
+		{"invalid center", Stadium{Stadium: "memorial", Name: "Memorial Stadium",
+			GeoRegion: &PollGeoRegion{Center: &GeoPoint{Latitude: 91, Longitude: 0}, Radius: 300}}, true},
+		{"zero radius", Stadium{Stadium: "memorial", Name: "Memorial Stadium", GeoRegion: &PollGeoRegion{Center: &testGeoCenter}}, true},
+		{"radius over the max", Stadium{Stadium: "memorial", Name: "Memorial Stadium",
+			GeoRegion: &PollGeoRegion{Center: &testGeoCenter, Radius: MaxGeoFenceRadius + 1}}, true},
+		{"geo-fenced polls without region", Stadium{Stadium: "memorial", Name: "Memorial Stadium", PollDefaults: StadiumPollDefaults{Geo: true}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.stadium.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidStadium) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalidStadium)
+			}
+		})
+	}
+}
+
+func TestStadiumApplyPollDefaults(t *testing.T) {
+	stadiumRegion := &PollGeoRegion{Center: &testGeoCenter, Radius: 300}
+	pollRegion := &PollGeoRegion{Center: &testGeoCenter, Radius: 50}
+
+	tests := []struct {
+		name       string
+		defaults   StadiumPollDefaults
+		poll       PollData
+		want       PollData
+		wantRegion *PollGeoRegion
+	}{
+		{"no defaults", StadiumPollDefaults{}, PollData{}, PollData{}, nil},
+		{"defaults turned on", StadiumPollDefaults{ShowResults: true, Geo: true, Anonymous: true, Repeat: true}, PollData{},
+			PollData{ShowResults: true, Geo: true, Anonymous: true, Repeat: true}, stadiumRegion},
+		{"poll settings are kept", StadiumPollDefaults{}, PollData{ShowResults: true, Anonymous: true, Repeat: true},
+			PollData{ShowResults: true, Anonymous: true, Repeat: true}, nil},
+		{"poll region is kept", StadiumPollDefaults{Geo: true}, PollData{GeoRegion: pollRegion}, PollData{Geo: true}, pollRegion},
+		{"geo-fenced poll without region", StadiumPollDefaults{}, PollData{Geo: true}, PollData{Geo: true}, stadiumRegion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stadium := Stadium{GeoRegion: stadiumRegion, PollDefaults: tt.defaults}
+			poll := tt.poll
+			stadium.ApplyPollDefaults(&poll)
+
+			if poll.ShowResults != tt.want.ShowResults || poll.Geo != tt.want.Geo || poll.Anonymous != tt.want.Anonymous || poll.Repeat != tt.want.Repeat {
+				t.Errorf("ApplyPollDefaults() = %+v, want %+v", poll, tt.want)
+			}
+			if (poll.GeoRegion == nil) != (tt.wantRegion == nil) || (poll.GeoRegion != nil && poll.GeoRegion.Radius != tt.wantRegion.Radius) {
+				t.Errorf("ApplyPollDefaults() region = %v, want %v", poll.GeoRegion, tt.wantRegion)
+			}
+			if poll.GeoRegion != nil && poll.GeoRegion == stadium.GeoRegion {
+				t.Errorf("ApplyPollDefaults() region is shared with the stadium")
+			}
+		})
+	}
+}
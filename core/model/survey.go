@@ -15,6 +15,7 @@
 package model
 
 import (
+	"sort"
 	"time"
 )
 
@@ -69,10 +70,36 @@ type Survey struct {
 	Strings            map[string]interface{} `json:"strings" bson:"strings"`
 	SubRules           map[string]interface{} `json:"sub_rules" bson:"sub_rules"`
 	ResponseKeys       []string               `json:"response_keys" bson:"response_keys"`
+	SearchTexts        []string               `json:"-" bson:"search_texts,omitempty"` // the texts of the survey data, indexed for the full-text search
 	DateCreated        time.Time              `json:"date_created" bson:"date_created"`
 	DateUpdated        *time.Time             `json:"date_updated" bson:"date_updated"`
 }
 
+// GetSearchTexts gets the texts of the survey data sorted by the data key
+func (s *Survey) GetSearchTexts() []string {
+	keys := make([]string, 0, len(s.Data))
+	for key := range s.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	texts := []string{}
+	for _, key := range keys {
+		if text := s.Data[key].Text; len(text) > 0 {
+			texts = append(texts, text)
+		}
+	}
+	return texts
+}
+
+// SurveysFilter wraps the filters for retrieving surveys
+type SurveysFilter struct {
+	Search string   `json:"search,omitempty"` // full-text search over the title and the texts of the survey data, the most relevant surveys first
+	Types  []string `json:"types,omitempty"`
+	Offset *int64   `json:"offset,omitempty"`
+	Limit  *int64   `json:"limit,omitempty"`
+} // @name SurveysFilter
+
 // SurveyStats are stats of a Survey
 type SurveyStats struct {
 	Total         int                    `json:"total" bson:"total"`
@@ -40,4 +40,5 @@ type UserDataResponse struct {
 	Poll           []Poll           `json:"my_polls"`
 	Surveys        []Survey         `json:"my_surveys"`
 	SurveyResponse []SurveyResponse `json:"participated_surveys"`
+	PollVotes      []PollUserVotes  `json:"poll_votes"`
 } //@name UserDataResponse
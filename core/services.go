@@ -16,11 +16,14 @@ package core
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"polls/core/model"
 	"polls/driven/groups"
-	"polls/driven/storage"
+	"polls/utils"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -47,6 +50,34 @@ func (app *Application) getPolls(user *model.User, filter model.PollsFilter, fil
 	return app.storage.GetPolls(user, filter, filterByToMembers, membership)
 }
 
+// getPollsPage gets a page of the polls after the cursor of the filter, and the cursor of the next page if there is one
+func (app *Application) getPollsPage(user *model.User, filter model.PollsFilter, filterByToMembers bool) ([]model.Poll, *string, error) {
+	limit := int64(model.DefaultPageLimit)
+	if filter.Limit != nil && *filter.Limit > 0 {
+		limit = *filter.Limit
+	}
+	if filter.Cursor == nil {
+		firstPage := ""
+		filter.Cursor = &firstPage
+	}
+
+	//one more poll tells if there is a next page
+	fetchLimit := limit + 1
+	filter.Limit = &fetchLimit
+	polls, err := app.getPolls(user, filter, filterByToMembers)
+	if err != nil {
+		return nil, nil, err
+	}
+	if int64(len(polls)) <= limit {
+		return polls, nil, nil
+	}
+
+	polls = polls[:limit]
+	last := polls[limit-1]
+	nextCursor := (&model.PageCursor{ID: last.ID.Hex()}).Encode()
+	return polls, &nextCursor, nil
+}
+
 func (app *Application) getPoll(user *model.User, id string) (*model.Poll, error) {
 	groupMembership, err := app.groups.GetGroupsMembership(user.Token)
 	if err != nil {
@@ -54,359 +85,1391 @@ func (app *Application) getPoll(user *model.User, id string) (*model.Poll, error
 		return nil, fmt.Errorf("error app.getPoll() - unable to retrieve user groups - %s", err)
 	}
 
-	return app.storage.GetPoll(user, id, true, groupMembership)
+	return app.storage.GetPoll(user, id, true, groupMembership)
+}
+
+func (app *Application) getPollByPin(user *model.User, pin int) (*model.Poll, error) {
+	groupMembership, err := app.groups.GetGroupsMembership(user.Token)
+	if err != nil {
+		log.Printf("error app.getPollByPin() - unable to retrieve user groups - %s", err)
+		return nil, fmt.Errorf("error app.getPollByPin() - unable to retrieve user groups - %s", err)
+	}
+
+	return app.storage.GetPollByPin(user, pin, groupMembership)
+}
+
+func (app *Application) createPoll(user *model.User, poll model.Poll) (*model.Poll, error) {
+	//the question, the options and the settings come from the template
+	if len(poll.TemplateID) > 0 {
+		template, err := app.getPollTemplate(user, poll.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		poll.PollData = template.Poll.NewInstance(poll.PollData)
+	}
+
+	if len(poll.Stadium) > 0 {
+		stadium, err := app.storage.GetStadiumByKey(user.Claims.OrgID, poll.Stadium)
+		if err != nil {
+			return nil, err
+		}
+		if stadium != nil {
+			stadium.ApplyPollDefaults(&poll.PollData)
+		}
+	}
+
+	err := poll.Validate()
+	if err != nil {
+		return nil, err
+	}
+	err = poll.ValidateGeoFence(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	//the polls are added to a session by the session
+	poll.SessionID = ""
+
+	//the co-owners are added by the owners of the created poll
+	poll.CoOwners = nil
+
+	//the instances are added to a series by the recurrence
+	poll.SeriesID = ""
+	poll.NextInstanceAt = nil
+	poll.InstancesCount = 0
+	if poll.Recurrence != nil {
+		anchor := time.Now().UTC()
+		if poll.StartAt != nil {
+			anchor = *poll.StartAt
+		}
+		poll.InstancesCount = 1
+		poll.NextInstanceAt = poll.Recurrence.Next(anchor, anchor, poll.InstancesCount)
+	}
+
+	//a poll is created as created or started, the other statuses are reached by transitions only
+	poll.Transitions = nil
+	switch poll.Status {
+	case model.PollStatusCreated:
+	case model.PollStatusStarted:
+		transition, err := model.NewPollStatusTransition(user, model.PollStatusCreated, model.PollActionStart)
+		if err != nil {
+			return nil, err
+		}
+		poll.Transitions = []model.PollStatusTransition{*transition}
+	default:
+		return nil, fmt.Errorf("%w: a poll can not be created as %s", model.ErrInvalidPollTransition, poll.Status)
+	}
+
+	createdPoll, err := app.storage.CreatePoll(user, poll)
+	if err != nil {
+		return nil, err
+	}
+
+	app.notifyNotificationsBBForPoll(user, createdPoll, "polls", "poll_created", "Poll '%s' has been created")
+
+	if poll.GroupID != nil {
+		go app.groups.UpdateGroupDateUpdated(*poll.GroupID)
+	}
+
+	return createdPoll, nil
+}
+
+func (app *Application) updatePoll(user *model.User, poll model.Poll, admin bool) (*model.Poll, error) {
+	err := poll.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	//get the poll
+	persistedPoll, err := app.getManagedPoll(user, poll.ID.Hex(), "update", admin)
+	if err != nil {
+		return nil, err
+	}
+
+	//the answers are interpreted by the poll type, the scale and the options
+	if !persistedPoll.HasSameAnswerFormat(poll.PollData) && persistedPoll.Counters != nil && persistedPoll.Counters.UniqueVoters > 0 {
+		return nil, fmt.Errorf("%w: the poll type, scale and options can not be changed once the poll has votes", model.ErrInvalidPoll)
+	}
+
+	err = poll.ValidateGeoFence(&persistedPoll.PollData)
+	if err != nil {
+		return nil, err
+	}
+
+	//the voters of an anonymous poll must stay anonymous
+	if persistedPoll.Anonymous && !poll.Anonymous {
+		return nil, fmt.Errorf("%w: an anonymous poll can not be made public", model.ErrInvalidPoll)
+	}
+
+	//the status is changed by the transitions only
+	if len(poll.Status) > 0 && poll.Status != persistedPoll.Status {
+		return nil, fmt.Errorf("%w: the status of a %s poll can not be updated to %s, use the poll actions", model.ErrInvalidPollTransition, persistedPoll.Status, poll.Status)
+	}
+	poll.UserID = persistedPoll.UserID
+	poll.UserName = persistedPoll.UserName
+	poll.Status = persistedPoll.Status
+	poll.Transitions = persistedPoll.Transitions
+	poll.SessionID = persistedPoll.SessionID
+	poll.CoOwners = persistedPoll.CoOwners
+
+	//the instances do not recur, the recurring poll creates them
+	poll.SeriesID = persistedPoll.SeriesID
+	if len(poll.SeriesID) > 0 && poll.Recurrence != nil {
+		return nil, fmt.Errorf("%w: an instance of a recurring poll can not recur", model.ErrInvalidPoll)
+	}
+	poll.InstancesCount = persistedPoll.InstancesCount
+	poll.NextInstanceAt = nil
+	if poll.Recurrence != nil {
+		poll.DateCreated = persistedPoll.DateCreated
+		poll.InstancesCount = max(poll.InstancesCount, 1)
+		poll.NextInstanceAt = poll.Recurrence.Next(poll.RecurrenceAnchor(), time.Now().UTC(), poll.InstancesCount)
+	}
+
+	//update the poll
+	updatedPoll, err := app.storage.UpdatePoll(user, poll)
+	if err != nil {
+		return nil, err
+	}
+
+	//the new auto close limits may be reached already
+	if updatedPoll.Status == model.PollStatusStarted && updatedPoll.AutoClose != nil && persistedPoll.Counters != nil {
+		reason := updatedPoll.AutoClose.Reason(*persistedPoll.Counters)
+		if len(reason) > 0 {
+			updatedPoll.Counters = persistedPoll.Counters
+			app.autoClosePoll(updatedPoll, reason)
+		}
+	}
+
+	return updatedPoll, nil
+}
+
+func (app *Application) deletePoll(user *model.User, id string, admin bool) error {
+	//get the poll
+	_, err := app.getManagedPoll(user, id, "delete", admin)
+	if err != nil {
+		return err
+	}
+
+	//delete the poll
+	err = app.storage.DeletePoll(user, id)
+	if err != nil {
+		return err
+	}
+
+	app.sseServer.NotifyPollForEvent(id, "poll_deleted")
+	app.sseServer.ClosePoll(id)
+
+	return nil
+}
+
+func (app *Application) deletePollsWithGroupID(user *model.User, groupID string) error {
+
+	ids, err := app.storage.DeletePollsWithGroupID(nil, groupID) // don't pass orgID (due to wrong value)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		app.sseServer.NotifyPollForEvent(id, "poll_deleted")
+		app.sseServer.ClosePoll(id)
+	}
+
+	return nil
+}
+
+// clonePoll creates a poll with the content of a poll the user can manage. The new poll is created in the requested group.
+func (app *Application) clonePoll(user *model.User, pollID string, request model.PollCloneRequest) (*model.Poll, error) {
+	source, err := app.getManagedPoll(user, pollID, "clone", false)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := model.PollData{Status: model.PollStatusCreated, GroupID: source.GroupID, ToMembersList: source.ToMembersList}
+	if request.GroupID != nil && utils.GetString(request.GroupID) != utils.GetString(source.GroupID) {
+		//the members are selected from the group
+		instance.GroupID = nil
+		instance.ToMembersList = nil
+		if len(*request.GroupID) > 0 {
+			groupID := *request.GroupID
+			instance.GroupID = &groupID
+		}
+	}
+
+	return app.createPoll(user, model.Poll{PollData: source.NewInstance(instance)})
+}
+
+// createPollInstance creates the next instance of a recurring poll. The instance is started, it gets the content, the owner,
+// the members and the group of the recurring poll. Returns nil if the instance has already been created by someone else.
+func (app *Application) createPollInstance(series model.Poll, now time.Time) (*model.Poll, error) {
+	if series.Recurrence == nil {
+		return nil, nil
+	}
+
+	//the missed instances are not created, e.g. after a downtime
+	instancesCount := series.InstancesCount + 1
+	next := series.Recurrence.Next(series.RecurrenceAnchor(), now, instancesCount)
+
+	//the instance is open from its scheduled time, so a late scheduler run does not move the next instances
+	start := now
+	if series.NextInstanceAt != nil {
+		start = *series.NextInstanceAt
+	}
+
+	transition, err := model.NewPollStatusTransition(nil, model.PollStatusCreated, model.PollActionStart)
+	if err != nil {
+		return nil, err
+	}
+	transition.Reason = model.PollTransitionReasonSchedule
+
+	instance := model.Poll{OrgID: series.OrgID, PollData: series.NewInstance(model.PollData{
+		UserID:        series.UserID,
+		UserName:      series.UserName,
+		CoOwners:      series.CoOwners,
+		ToMembersList: series.ToMembersList,
+		GroupID:       series.GroupID,
+		AutoPin:       series.Pin > 0,
+		Status:        model.PollStatusStarted,
+		EndAt:         series.Recurrence.InstanceEnd(start, next),
+		SeriesID:      series.ID.Hex(),
+	})}
+	instance.Transitions = []model.PollStatusTransition{*transition}
+
+	//the instance is created and the series moves to its next instance in one transaction
+	createdPoll, err := app.storage.CreatePollInstance(series, instance, next, instancesCount)
+	if err != nil || createdPoll == nil {
+		return nil, err
+	}
+
+	app.notifyNotificationsBBForPoll(nil, createdPoll, "polls", "poll_created", "Poll '%s' has been created")
+
+	if createdPoll.GroupID != nil {
+		go app.groups.UpdateGroupDateUpdated(*createdPoll.GroupID)
+	}
+
+	return createdPoll, nil
+}
+
+// getPollSeries gets the polls of the series of a recurring poll or of its instance, with the results the user can see
+func (app *Application) getPollSeries(user *model.User, id string) (*model.PollSeries, error) {
+	groupMembership, err := app.groups.GetGroupsMembership(user.Token)
+	if err != nil {
+		log.Printf("error app.getPollSeries() - unable to retrieve user groups - %s", err)
+		return nil, fmt.Errorf("error app.getPollSeries() - unable to retrieve user groups - %s", err)
+	}
+
+	poll, err := app.storage.GetPoll(user, id, true, groupMembership)
+	if err != nil {
+		return nil, err
+	}
+
+	seriesID := poll.SeriesID
+	if len(seriesID) == 0 {
+		seriesID = poll.ID.Hex()
+	}
+	polls, err := app.storage.GetPollSeries(user, seriesID, groupMembership)
+	if err != nil {
+		return nil, err
+	}
+
+	series := model.NewPollSeries(seriesID, polls, user.Claims.Subject)
+	return &series, nil
+}
+
+// addPollCoOwner gives the user the same rights to manage the poll as its creator. The co-owners can not add other co-owners,
+// only the creator of the poll or a group admin can.
+func (app *Application) addPollCoOwner(user *model.User, pollID string, userID string) (*model.Poll, error) {
+	poll, err := app.getManagedPoll(user, pollID, "add co-owners to", false)
+	if err != nil {
+		return nil, err
+	}
+	if poll.IsCoOwner(user.Claims.Subject) {
+		err = app.checkPollCreatorPermission(user, poll, "add co-owners to")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(strings.TrimSpace(userID)) == 0 {
+		return nil, fmt.Errorf("%w: empty co-owner", model.ErrInvalidPoll)
+	}
+	if userID == poll.UserID {
+		return nil, fmt.Errorf("%w: the creator of the poll can not be a co-owner", model.ErrInvalidPoll)
+	}
+	if poll.IsCoOwner(userID) {
+		return poll, nil
+	}
+
+	err = app.storage.AddPollCoOwner(poll.OrgID, poll.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	poll.CoOwners = append(poll.CoOwners, userID)
+	return poll, nil
+}
+
+// removePollCoOwner removes the management rights of a co-owner. A co-owner can remove themselves, the other co-owners are
+// removed by the creator of the poll or a group admin.
+func (app *Application) removePollCoOwner(user *model.User, pollID string, userID string) (*model.Poll, error) {
+	poll, err := app.getManagedPoll(user, pollID, "remove co-owners from", false)
+	if err != nil {
+		return nil, err
+	}
+	if poll.IsCoOwner(user.Claims.Subject) && userID != user.Claims.Subject {
+		err = app.checkPollCreatorPermission(user, poll, "remove co-owners from")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !poll.IsCoOwner(userID) {
+		return poll, nil
+	}
+
+	err = app.storage.RemovePollCoOwner(poll.OrgID, poll.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	poll.CoOwners = slices.DeleteFunc(poll.CoOwners, func(coOwner string) bool { return coOwner == userID })
+	return poll, nil
+}
+
+func (app *Application) startPoll(user *model.User, pollID string) error {
+	poll, transition, err := app.changePollStatus(user, pollID, model.PollActionStart, false)
+	if err != nil {
+		return err
+	}
+
+	if transition.FromStatus == model.PollStatusPaused {
+		app.sseServer.NotifyPollForEvent(poll.ID.Hex(), "poll_resumed")
+	} else {
+		app.onPollStarted(user, poll)
+	}
+
+	return nil
+}
+
+func (app *Application) pausePoll(user *model.User, pollID string) error {
+	poll, _, err := app.changePollStatus(user, pollID, model.PollActionPause, false)
+	if err != nil {
+		return err
+	}
+
+	app.sseServer.NotifyPollForEvent(poll.ID.Hex(), "poll_paused")
+
+	return nil
+}
+
+func (app *Application) reopenPoll(user *model.User, pollID string) error {
+	poll, _, err := app.changePollStatus(user, pollID, model.PollActionReopen, false)
+	if err != nil {
+		return err
+	}
+
+	app.notifyNotificationsBBForPoll(user, poll, "polls", "poll_reopened", "Poll '%s' has been reopened")
+
+	if poll.GroupID != nil {
+		go app.groups.UpdateGroupDateUpdated(*poll.GroupID)
+	}
+
+	return nil
+}
+
+// getManagedPoll gets a poll the user can manage. An admin can manage all polls of the organization.
+func (app *Application) getManagedPoll(user *model.User, pollID string, operation string, admin bool) (*model.Poll, error) {
+	if admin {
+		poll, err := app.storage.GetPoll(user, pollID, false, nil)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("admin %s performs %s on poll %s of user %s", user.Claims.Subject, operation, pollID, poll.UserID)
+		return poll, nil
+	}
+
+	groupMembership, err := app.groups.GetGroupsMembership(user.Token)
+	if err != nil {
+		return nil, fmt.Errorf("error getting poll when %s - %s", operation, err)
+	}
+	poll, err := app.storage.GetPoll(user, pollID, true, groupMembership)
+	if err != nil {
+		return nil, err
+	}
+
+	//check permission
+	err = app.checkPollPermission(user, poll, operation)
+	if err != nil {
+		return nil, err
+	}
+	return poll, nil
+}
+
+// changePollStatus applies the action to the poll status if the user can manage the poll and the action is allowed in the current status
+func (app *Application) changePollStatus(user *model.User, pollID string, action string, admin bool) (*model.Poll, *model.PollStatusTransition, error) {
+	poll, err := app.getManagedPoll(user, pollID, action, admin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transition, err := model.NewPollStatusTransition(user, poll.Status, action)
+	if err != nil {
+		return nil, nil, err
+	}
+	if action == model.PollActionReopen && poll.EndAt != nil && !poll.EndAt.After(transition.Date) {
+		return nil, nil, fmt.Errorf("%w: the end time of the poll has passed, update it before reopening", model.ErrInvalidPollTransition)
+	}
+	if transition.ToStatus == model.PollStatusStarted && poll.AutoClose != nil && poll.Counters != nil {
+		err = poll.AutoClose.ValidateStart(*poll.Counters)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	updated, err := app.storage.UpdatePollStatus(*poll, *transition)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !updated {
+		return nil, nil, fmt.Errorf("%w: the poll has been changed", model.ErrInvalidPollTransition)
+	}
+
+	poll.Status = transition.ToStatus
+	poll.Transitions = append(poll.Transitions, *transition)
+	return poll, transition, nil
+}
+
+// onPollStarted notifies about a started poll. The user is nil when the poll is started by the scheduler.
+func (app *Application) onPollStarted(user *model.User, poll *model.Poll) {
+	app.notifyNotificationsBBForPoll(user, poll, "polls", "poll_started", "Poll '%s' has been started")
+
+	app.sseServer.NotifyPollForEvent(poll.ID.Hex(), "poll_started")
+
+	if poll.GroupID != nil {
+		go app.groups.UpdateGroupDateUpdated(*poll.GroupID)
+	}
+}
+
+func (app *Application) endPoll(user *model.User, pollID string, admin bool) error {
+	poll, _, err := app.changePollStatus(user, pollID, model.PollActionEnd, admin)
+	if err != nil {
+		return err
+	}
+
+	app.onPollEnded(user, poll)
+
+	return nil
+}
+
+// onPollEnded notifies about an ended poll. The user is nil when the poll is ended by the scheduler.
+func (app *Application) onPollEnded(user *model.User, poll *model.Poll) {
+	app.notifyNotificationsBBForPoll(user, poll, "polls", "poll_ended", "Poll '%s' has ended.")
+
+	if poll.Quiz != nil {
+		//the scoring reads all votes, so it runs in the background and the stream is closed once the subscribers get the scores
+		go func() {
+			leaderboards := app.scoreQuizPoll(poll)
+			app.sseServer.NotifyQuizEnded(poll.ID.Hex(), poll.Quiz.CorrectOptions, leaderboards)
+			app.closePollEvents(poll.ID.Hex())
+		}()
+	} else {
+		app.closePollEvents(poll.ID.Hex())
+	}
+
+	if poll.GroupID != nil {
+		go app.groups.UpdateGroupDateUpdated(*poll.GroupID)
+	}
+}
+
+// closePollEvents notifies the subscribers of an ended poll and closes their streams
+func (app *Application) closePollEvents(pollID string) {
+	app.sseServer.NotifyPollForEvent(pollID, "poll_end")
+	app.sseServer.ClosePoll(pollID)
+}
+
+// notifyNotificationsBBForPoll sends a poll notification. The user is nil for the system triggered operations.
+// The message format gets the poll question. The to members get the question in their locale where it is known,
+// so one notification is sent per localized variant.
+func (app *Application) notifyNotificationsBBForPoll(user *model.User, poll *model.Poll, topic string, operation string, messageFormat string) {
+	sender := &model.Sender{Type: "system"}
+	appID := "" // the notifications adapter uses the configured app when it is empty
+	if user != nil {
+		sender = &model.Sender{
+			Type: "user",
+			User: &model.UserRef{
+				UserID: user.Claims.Subject,
+				Name:   user.Claims.Name,
+			},
+		}
+		appID = user.Claims.AppID
+	}
+
+	subject := "Illinois"
+	if poll.GroupID != nil && user != nil {
+		group, _ := app.groups.GetGroupDetails(user.Token, *poll.GroupID)
+		if group != nil {
+			subject = fmt.Sprintf("Group - %s", group.Title)
+		}
+	}
+
+	membersByLocale := map[string]model.ToMembers{"": poll.ToMembersList}
+	if len(poll.ToMembersList) > 0 {
+		membersByLocale = poll.ToMembersList.GroupByLocale(&poll.PollData)
+	}
+
+	for locale, members := range membersByLocale {
+		question := poll.LocalizedQuestion(locale)
+		message := fmt.Sprintf(messageFormat, question)
+
+		if poll.GroupID != nil {
+			app.groups.SendGroupNotification(*poll.GroupID, model.GroupNotification{
+				Members: members.ToNotificationRecipients(),
+				Sender:  sender,
+				Topic:   &topic,
+				Subject: subject,
+				Body:    message,
+				Data: map[string]string{
+					"group_id":    *poll.GroupID,
+					"type":        "poll",
+					"operation":   operation,
+					"entity_type": "poll",
+					"entity_id":   poll.ID.Hex(),
+					"entity_name": question,
+				},
+			})
+		} else {
+			app.notifications.SendNotification(model.NotificationMessage{
+				Message: model.InnerMessage{
+					AppID:      appID,
+					OrgID:      poll.OrgID,
+					Recipients: members.ToNotificationRecipients(),
+					Sender:     sender,
+					Topic:      &topic,
+					Subject:    subject,
+					Body:       message,
+					Data: map[string]string{
+						"type":        "poll",
+						"operation":   operation,
+						"entity_type": "poll",
+						"entity_id":   poll.ID.Hex(),
+						"entity_name": question,
+					},
+				},
+			})
+		}
+	}
+}
+
+func (app *Application) votePoll(user *model.User, pollID string, vote model.PollVote) error {
+	vote.UserID = user.Claims.Subject
+
+	//a text answer waits for moderation, the other answers are never moderated
+	vote.ID = ""
+	vote.Status = ""
+	vote.Pinned = false
+	if len(vote.Text) > 0 {
+		vote.Text = strings.TrimSpace(vote.Text)
+		vote.ID = uuid.NewString()
+		vote.Status = model.TextAnswerStatusPending
+	}
+
+	poll, err := app.storage.VotePoll(user, pollID, vote)
+	if err != nil {
+		return err
+	}
+
+	//the text results change when an answer is replaced only, the new answers wait for moderation
+	if poll.IsRanked() || (poll.IsOpenText() && poll.AllowVoteChange) {
+		app.pollTally.request(poll)
+	}
+
+	if poll.AutoClose != nil && poll.Counters != nil {
+		reason := poll.AutoClose.Reason(*poll.Counters)
+		if len(reason) > 0 {
+			app.autoClosePoll(poll, reason)
+		}
+	}
+	return nil
+}
+
+// autoClosePoll ends a poll which has reached an auto close limit. The vote is accepted even if the poll can not be ended.
+func (app *Application) autoClosePoll(poll *model.Poll, reason string) {
+	transition, err := model.NewPollStatusTransition(nil, poll.Status, model.PollActionEnd)
+	if err != nil {
+		log.Printf("error app.autoClosePoll(%s) - %s", poll.ID.Hex(), err)
+		return
+	}
+	transition.Reason = reason
+
+	updated, err := app.storage.UpdatePollStatus(*poll, *transition)
+	if err != nil {
+		log.Printf("error app.autoClosePoll(%s) - %s", poll.ID.Hex(), err)
+		return
+	}
+	if !updated {
+		// already ended
+		return
+	}
+
+	log.Printf("poll %s auto closed - %s", poll.ID.Hex(), reason)
+	poll.Status = model.PollStatusTerminated
+	poll.Transitions = append(poll.Transitions, *transition)
+	app.onPollEnded(nil, poll)
+}
+
+func (app *Application) retractPollVote(user *model.User, pollID string) error {
+	err := app.storage.RetractPollVote(user, pollID)
+	if err != nil {
+		return err
+	}
+
+	//the tally logic skips the polls without a tally
+	app.pollTally.add(pollID, user.Claims.OrgID)
+	return nil
+}
+
+func (app *Application) exportPollResults(user *model.User, pollID string, writer PollResultsWriter) error {
+	poll, err := app.storage.GetPoll(user, pollID, false, nil)
+	if err != nil {
+		return err
+	}
+
+	err = app.checkPollPermission(user, poll, "export")
+	if err != nil {
+		return err
+	}
+
+	// the exporting user can manage the poll, so the results are exported as the creator sees them
+	err = writer.WriteResult(poll.ToPollResult(poll.UserID))
+	if err != nil {
+		return err
+	}
+
+	if poll.Anonymous {
+		// the voter identities are never returned
+		return nil
+	}
+	return app.storage.ExportPollVotes(poll.OrgID, pollID, func(userVotes model.PollUserVotes) error {
+		for _, vote := range userVotes.Votes {
+			err := writer.WriteVote(poll.ToPollExportVote(userVotes.UserID, vote))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (app *Application) getPollTextAnswers(user *model.User, pollID string) ([]model.PollTextAnswer, error) {
+	poll, err := app.getModeratedPoll(user, pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.storage.GetPollTextAnswers(poll.OrgID, pollID, !poll.Anonymous)
+}
+
+func (app *Application) moderatePollTextAnswer(user *model.User, pollID string, answerID string, moderation model.PollTextModeration) error {
+	if moderation.Status != nil && *moderation.Status != model.TextAnswerStatusApproved && *moderation.Status != model.TextAnswerStatusHidden {
+		return fmt.Errorf("%w: status must be %s or %s", model.ErrInvalidVote, model.TextAnswerStatusApproved, model.TextAnswerStatusHidden)
+	}
+
+	poll, err := app.getModeratedPoll(user, pollID)
+	if err != nil {
+		return err
+	}
+
+	err = app.storage.ModeratePollTextAnswer(poll.OrgID, pollID, answerID, moderation)
+	if err != nil {
+		return err
+	}
+
+	app.pollTally.request(poll)
+	return nil
+}
+
+// getModeratedPoll gets an open text poll the user can moderate
+func (app *Application) getModeratedPoll(user *model.User, pollID string) (*model.Poll, error) {
+	poll, err := app.storage.GetPoll(user, pollID, false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = app.checkPollPermission(user, poll, "moderate")
+	if err != nil {
+		return nil, err
+	}
+
+	if !poll.IsOpenText() {
+		return nil, fmt.Errorf("%w: poll %s has no text answers", model.ErrInvalidPoll, pollID)
+	}
+	return poll, nil
+}
+
+func (app *Application) subscribeToPoll(user *model.User, pollID string, resultChan chan map[string]interface{}) error {
+	app.sseServer.RegisterUserForPoll(user.Claims.Subject, pollID, resultChan)
+	return nil
+}
+
+// checkPollPermission checks if the user can manage the poll, i.e. is its creator, a co-owner or a group admin
+func (app *Application) checkPollPermission(user *model.User, poll *model.Poll, operation string) error {
+	//the co-owners have the same rights as the creator
+	if poll != nil && poll.IsCoOwner(user.Claims.Subject) {
+		return nil
+	}
+	return app.checkPollCreatorPermission(user, poll, operation)
+}
+
+// checkPollCreatorPermission checks if the user is the creator of the poll or a group admin
+func (app *Application) checkPollCreatorPermission(user *model.User, poll *model.Poll, operation string) error {
+	if poll != nil {
+		if user.Claims.Subject != poll.UserID {
+			if poll.GroupID != nil && len(*poll.GroupID) > 0 {
+				group, err := app.groups.GetGroupDetails(user.Token, *poll.GroupID)
+				if err != nil {
+					return err
+				}
+				if group != nil {
+					if !group.IsCurrentUserAdmin(user.Claims.Subject) {
+						return fmt.Errorf("%w: only the creator of a poll or a group admin can %s it", model.ErrPollPermission, operation)
+					}
+				} else {
+					return fmt.Errorf("%w: only the creator of a poll or a group admin can %s it", model.ErrPollPermission, operation)
+				}
+			} else {
+				return fmt.Errorf("%w: only the creator of a poll can %s it", model.ErrPollPermission, operation)
+			}
+		}
+	} else {
+		return fmt.Errorf("poll is nil")
+	}
+	return nil
+}
+
+// OnCollectionUpdated callback that indicates the reward types collection is changed
+func (app *Application) OnCollectionUpdated(collection string, record map[string]interface{}) {
+	if "polls" == collection && record != nil {
+		data, err := json.Marshal(record)
+		if err != nil {
+			log.Printf("Error on Application.OnCollectionUpdated: %s", err)
+			return
+		}
+
+		if data != nil {
+			var poll model.PollNotification
+			err = json.Unmarshal(data, &poll)
+			if err != nil {
+				log.Printf("Error on Application.OnCollectionUpdated: %s", err)
+				return
+			}
+
+			app.sseServer.NotifyPollUpdate(poll.ID.Hex(), poll, app.getSubscribedVoterIDs(poll))
+
+			if poll.StartAt != nil || poll.EndAt != nil || poll.Recurrence != nil {
+				app.pollSchedule.reschedule()
+			}
+		}
+	}
+}
+
+// getSubscribedVoterIDs gets the subscribers who have voted, if the poll shows the results after voting
+func (app *Application) getSubscribedVoterIDs(poll model.PollNotification) map[string]bool {
+	voterIDs := map[string]bool{}
+	if poll.Status == model.PollStatusTerminated || poll.GetResultsVisibility() != model.ResultsVisibilityAfterVote {
+		return voterIDs
+	}
+
+	userIDs := app.sseServer.GetPollUserIDs(poll.ID.Hex())
+	if len(userIDs) == 0 {
+		return voterIDs
+	}
+	ids, err := app.storage.GetPollVoterIDs(poll.OrgID, poll.ID.Hex(), userIDs)
+	if err != nil {
+		// the results stay hidden for this update
+		log.Printf("Error on Application.getSubscribedVoterIDs: %s", err)
+		return voterIDs
+	}
+	for _, id := range ids {
+		voterIDs[id] = true
+	}
+	return voterIDs
+}
+
+func (app *Application) getSurveys(user *model.User, filter model.SurveysFilter) ([]model.Survey, error) {
+	return app.storage.GetSurveys(user, filter)
+}
+
+func (app *Application) getSurvey(user *model.User, id string) (*model.Survey, error) {
+	return app.storage.GetSurvey(user, id)
+}
+
+func (app *Application) createSurvey(user *model.User, survey model.Survey, admin bool) (*model.Survey, error) {
+	survey.ID = uuid.NewString()
+	survey.CreatorID = user.Claims.Subject
+	survey.DateCreated = time.Now().UTC()
+	survey.AppID = user.Claims.AppID
+	survey.OrgID = user.Claims.OrgID
+	if !admin {
+		survey.Type = "user"
+	}
+	return app.storage.CreateSurvey(survey)
+}
+
+func (app *Application) updateSurvey(user *model.User, survey model.Survey, id string, admin bool) error {
+	survey.ID = id
+	if !admin {
+		survey.Type = "user"
+	}
+	return app.storage.UpdateSurvey(user, survey, admin)
+}
+
+func (app *Application) deleteSurvey(user *model.User, id string, admin bool) error {
+	return app.storage.DeleteSurvey(user, id, admin)
+}
+
+func (app *Application) deleteSurveyResponses(user *model.User, surveyIDs []string, surveyTypes []string, startDate *time.Time, endDate *time.Time) error {
+	return app.storage.DeleteSurveyResponses(user, surveyIDs, surveyTypes, startDate, endDate)
+}
+
+func (app *Application) getSurveyResponse(user *model.User, id string) (*model.SurveyResponse, error) {
+	return app.storage.GetSurveyResponse(user, id)
+}
+
+func (app *Application) getSurveyResponses(user *model.User, surveyIDs []string, surveyTypes []string, startDate *time.Time, endDate *time.Time, limit *int, offset *int) ([]model.SurveyResponse, error) {
+	return app.storage.GetSurveyResponses(user, surveyIDs, surveyTypes, startDate, endDate, limit, offset, nil)
+}
+
+// getSurveyResponsesPage gets a page of the survey responses after the cursor, and the cursor of the next page if there is one
+func (app *Application) getSurveyResponsesPage(user *model.User, surveyIDs []string, surveyTypes []string, startDate *time.Time, endDate *time.Time, limit int, cursor string) ([]model.SurveyResponse, *string, error) {
+	if limit <= 0 {
+		limit = model.DefaultPageLimit
+	}
+
+	//one more response tells if there is a next page
+	fetchLimit := limit + 1
+	responses, err := app.storage.GetSurveyResponses(user, surveyIDs, surveyTypes, startDate, endDate, &fetchLimit, nil, &cursor)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(responses) <= limit {
+		return responses, nil, nil
+	}
+
+	responses = responses[:limit]
+	last := responses[limit-1]
+	nextCursor := (&model.PageCursor{ID: last.ID, Date: &last.DateCreated}).Encode()
+	return responses, &nextCursor, nil
+}
+
+func (app *Application) createSurveyResponse(user *model.User, survey model.Survey) (*model.SurveyResponse, error) {
+	response := model.SurveyResponse{ID: uuid.NewString(), AppID: user.Claims.AppID, OrgID: user.Claims.OrgID,
+		UserID: user.Claims.Subject, DateCreated: time.Now().UTC(), Survey: survey}
+	return app.storage.CreateSurveyResponse(response)
+}
+
+func (app *Application) updateSurveyResponse(user *model.User, id string, survey model.Survey) error {
+	return app.storage.UpdateSurveyResponse(user, id, survey)
+}
+
+func (app *Application) deleteSurveyResponse(user *model.User, id string) error {
+	return app.storage.DeleteSurveyResponse(user, id)
+}
+
+func (app *Application) getAlertContacts(user *model.User) ([]model.AlertContact, error) {
+	return app.storage.GetAlertContacts(user)
+}
+
+func (app *Application) getAlertContact(user *model.User, id string) (*model.AlertContact, error) {
+	return app.storage.GetAlertContact(user, id)
+}
+
+func (app *Application) createAlertContact(user *model.User, alertContact model.AlertContact) (*model.AlertContact, error) {
+	alertContact.ID = uuid.NewString()
+	alertContact.AppID = user.Claims.AppID
+	alertContact.OrgID = user.Claims.OrgID
+	alertContact.DateCreated = time.Now().UTC()
+	return app.storage.CreateAlertContact(alertContact)
+}
+
+func (app *Application) updateAlertContact(user *model.User, id string, alertContact model.AlertContact) error {
+	return app.storage.UpdateAlertContact(user, id, alertContact)
+}
+
+func (app *Application) deleteAlertContact(user *model.User, id string) error {
+	return app.storage.DeleteAlertContact(user, id)
+}
+
+func (app *Application) getStadiums(user *model.User) ([]model.Stadium, error) {
+	return app.storage.GetStadiums(user.Claims.OrgID)
+}
+
+func (app *Application) getStadium(user *model.User, id string) (*model.Stadium, error) {
+	return app.storage.GetStadium(user.Claims.OrgID, id)
+}
+
+func (app *Application) createStadium(user *model.User, stadium model.Stadium) (*model.Stadium, error) {
+	err := stadium.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	stadium.ID = uuid.NewString()
+	stadium.OrgID = user.Claims.OrgID
+	stadium.DateCreated = time.Now().UTC()
+	stadium.DateUpdated = nil
+	return app.storage.CreateStadium(stadium)
+}
+
+func (app *Application) updateStadium(user *model.User, id string, stadium model.Stadium) error {
+	err := stadium.Validate()
+	if err != nil {
+		return err
+	}
+
+	return app.storage.UpdateStadium(user.Claims.OrgID, id, stadium)
+}
+
+func (app *Application) deleteStadium(user *model.User, id string) error {
+	return app.storage.DeleteStadium(user.Claims.OrgID, id)
+}
+
+func (app *Application) getPollTemplates(user *model.User) ([]model.PollTemplate, error) {
+	membership, err := app.groups.GetGroupsMembership(user.Token)
+	if err != nil {
+		log.Printf("error app.getPollTemplates() - unable to retrieve user groups - %s", err)
+		return nil, fmt.Errorf("error app.getPollTemplates() - unable to retrieve user groups - %s", err)
+	}
+
+	groupIDs := []string{}
+	var adminGroupIDs []string
+	if membership != nil {
+		adminGroupIDs = membership.GroupIDsAsAdmin
+		groupIDs = append(groupIDs, membership.GroupIDsAsAdmin...)
+		groupIDs = append(groupIDs, membership.GroupIDsAsMember...)
+	}
+	templates, err := app.storage.GetPollTemplates(user.Claims.OrgID, user.Claims.Subject, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	//the quiz answers of a group template are available to the users who can manage it only
+	for i := range templates {
+		template := &templates[i]
+		if template.GroupID != nil && template.UserID != user.Claims.Subject && !slices.Contains(adminGroupIDs, *template.GroupID) {
+			template.HideQuizAnswers()
+		}
+	}
+	return templates, nil
+}
+
+func (app *Application) getPollTemplate(user *model.User, id string) (*model.PollTemplate, error) {
+	template, err := app.storage.GetPollTemplate(user.Claims.OrgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = app.checkPollTemplatePermission(user, template, "use", false)
+	if err != nil {
+		return nil, err
+	}
+
+	//the quiz answers are available to the users who can manage the template only
+	if template.Poll.Quiz != nil && app.checkPollTemplatePermission(user, template, "see the quiz answers of", true) != nil {
+		template.HideQuizAnswers()
+	}
+	return template, nil
+}
+
+func (app *Application) createPollTemplate(user *model.User, template model.PollTemplate) (*model.PollTemplate, error) {
+	template.Poll = template.Poll.CopyContent()
+	err := template.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	template.ID = uuid.NewString()
+	template.OrgID = user.Claims.OrgID
+	template.UserID = user.Claims.Subject
+	template.DateCreated = time.Now().UTC()
+	template.DateUpdated = nil
+	if template.GroupID != nil && len(*template.GroupID) == 0 {
+		template.GroupID = nil
+	}
+
+	//a group template is available to the group members
+	err = app.checkPollTemplatePermission(user, &template, "create", false)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.storage.CreatePollTemplate(template)
+}
+
+func (app *Application) updatePollTemplate(user *model.User, id string, template model.PollTemplate) (*model.PollTemplate, error) {
+	template.Poll = template.Poll.CopyContent()
+	err := template.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	persistedTemplate, err := app.storage.GetPollTemplate(user.Claims.OrgID, id)
+	if err != nil {
+		return nil, err
+	}
+	err = app.checkPollTemplatePermission(user, persistedTemplate, "update", true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = app.storage.UpdatePollTemplate(user.Claims.OrgID, id, template)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	persistedTemplate.Name = template.Name
+	persistedTemplate.Poll = template.Poll
+	persistedTemplate.DateUpdated = &now
+	return persistedTemplate, nil
+}
+
+func (app *Application) deletePollTemplate(user *model.User, id string) error {
+	template, err := app.storage.GetPollTemplate(user.Claims.OrgID, id)
+	if err != nil {
+		return err
+	}
+	err = app.checkPollTemplatePermission(user, template, "delete", true)
+	if err != nil {
+		return err
+	}
+
+	return app.storage.DeletePollTemplate(user.Claims.OrgID, id)
+}
+
+// checkPollTemplatePermission checks if the user can use the template, or manage it if manage is set. A personal template
+// is available to its creator only. A group template is available to the group members and managed by its creator and the group admins.
+func (app *Application) checkPollTemplatePermission(user *model.User, template *model.PollTemplate, operation string, manage bool) error {
+	if template.GroupID == nil {
+		if template.UserID != user.Claims.Subject {
+			return fmt.Errorf("%w: only the creator of a personal poll template can %s it", model.ErrPollPermission, operation)
+		}
+		return nil
+	}
+	if manage && template.UserID == user.Claims.Subject {
+		return nil
+	}
+
+	membership, err := app.groups.GetGroupsMembership(user.Token)
+	if err != nil {
+		return fmt.Errorf("error checking poll template permission when %s - %s", operation, err)
+	}
+	if membership != nil {
+		if slices.Contains(membership.GroupIDsAsAdmin, *template.GroupID) {
+			return nil
+		}
+		if !manage && slices.Contains(membership.GroupIDsAsMember, *template.GroupID) {
+			return nil
+		}
+	}
+
+	if manage {
+		return fmt.Errorf("%w: only the creator of a group poll template or a group admin can %s it", model.ErrPollPermission, operation)
+	}
+	return fmt.Errorf("%w: only the group members can %s a group poll template", model.ErrPollPermission, operation)
+}
+
+// scoreQuizPoll stores the scores of the voters of an ended quiz poll. Returns the updated leaderboards of the poll group and session.
+func (app *Application) scoreQuizPoll(poll *model.Poll) []model.QuizLeaderboard {
+	votes, err := app.storage.GetPollVotes(poll.OrgID, poll.ID.Hex())
+	if err != nil {
+		log.Printf("error app.scoreQuizPoll(%s) - unable to retrieve the poll votes - %s", poll.ID.Hex(), err)
+		return nil
+	}
+
+	err = app.storage.ReplaceQuizScores(poll.OrgID, poll.ID.Hex(), model.NewQuizScores(poll, votes))
+	if err != nil {
+		log.Printf("error app.scoreQuizPoll(%s) - unable to store the quiz scores - %s", poll.ID.Hex(), err)
+		return nil
+	}
+
+	var leaderboards []model.QuizLeaderboard
+	if poll.GroupID != nil {
+		leaderboard, err := app.loadQuizLeaderboard(poll.OrgID, poll.GroupID, "", 0)
+		if err == nil {
+			leaderboards = append(leaderboards, *leaderboard)
+		}
+	}
+	if len(poll.SessionID) > 0 {
+		leaderboard, err := app.loadQuizLeaderboard(poll.OrgID, nil, poll.SessionID, 0)
+		if err == nil {
+			leaderboards = append(leaderboards, *leaderboard)
+		}
+	}
+	return leaderboards
+}
+
+func (app *Application) getQuizLeaderboard(user *model.User, groupID *string, sessionID string, limit int) (*model.QuizLeaderboard, error) {
+	if (groupID == nil) == (len(sessionID) == 0) {
+		return nil, fmt.Errorf("%w: either a group or a session is required", model.ErrInvalidLeaderboardScope)
+	}
+
+	if groupID != nil {
+		//the group leaderboard is available to the group members
+		membership, err := app.groups.GetGroupsMembership(user.Token)
+		if err != nil {
+			log.Printf("error app.getQuizLeaderboard() - unable to retrieve user groups - %s", err)
+			return nil, fmt.Errorf("error app.getQuizLeaderboard() - unable to retrieve user groups - %s", err)
+		}
+		if membership == nil || (!slices.Contains(membership.GroupIDsAsAdmin, *groupID) && !slices.Contains(membership.GroupIDsAsMember, *groupID)) {
+			return nil, fmt.Errorf("%w: only the group members can see the group leaderboard", model.ErrPollPermission)
+		}
+	} else {
+		//the session leaderboard is available to the users who can follow the session
+		_, err := app.getPollSession(user, sessionID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return app.loadQuizLeaderboard(user.Claims.OrgID, groupID, sessionID, limit)
 }
 
-func (app *Application) createPoll(user *model.User, poll model.Poll) (*model.Poll, error) {
-	createdPoll, err := app.storage.CreatePoll(user, poll)
+func (app *Application) loadQuizLeaderboard(orgID string, groupID *string, sessionID string, limit int) (*model.QuizLeaderboard, error) {
+	entries, err := app.storage.GetQuizLeaderboard(orgID, groupID, sessionID, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	app.notifyNotificationsBBForPoll(user, createdPoll, "polls", "poll_created", fmt.Sprintf("Poll '%s' has been created", createdPoll.Question))
+	leaderboard := model.QuizLeaderboard{GroupID: groupID, SessionID: sessionID, Entries: entries}
+	leaderboard.SetRanks()
+	return &leaderboard, nil
+}
 
-	if poll.GroupID != nil {
-		go app.groups.UpdateGroupDateUpdated(*poll.GroupID)
+func (app *Application) getPollSessions(user *model.User) ([]model.PollSession, error) {
+	return app.storage.GetPollSessions(user.Claims.OrgID, user.Claims.Subject)
+}
+
+func (app *Application) getPollSession(user *model.User, id string) (*model.PollSession, error) {
+	session, err := app.storage.GetPollSession(user.Claims.OrgID, id)
+	if err != nil {
+		return nil, err
 	}
 
-	return createdPoll, nil
+	err = app.checkPollSessionAccess(user, session)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
 }
 
-func (app *Application) updatePoll(user *model.User, poll model.Poll) (*model.Poll, error) {
-	//get the poll
-	groupMembership, err := app.groups.GetGroupsMembership(user.Token)
+func (app *Application) getPollSessionByPin(user *model.User, pin int) (*model.PollSession, error) {
+	session, err := app.storage.GetPollSessionByPin(user.Claims.OrgID, pin)
 	if err != nil {
-		return nil, fmt.Errorf("error getting poll when update - %s", err)
+		return nil, err
 	}
-	persistedPoll, err := app.storage.GetPoll(user, poll.ID.Hex(), true, groupMembership)
+
+	err = app.checkPollSessionAccess(user, session)
 	if err != nil {
 		return nil, err
 	}
-	if persistedPoll == nil {
-		return nil, fmt.Errorf("poll not found")
-	}
+	return session, nil
+}
 
-	//check permission
-	err = app.checkPollPermission(user, persistedPoll, "update")
+func (app *Application) createPollSession(user *model.User, session model.PollSession) (*model.PollSession, error) {
+	err := session.Validate()
 	if err != nil {
 		return nil, err
 	}
 
-	//update the poll
-	updatedPoll, err := app.storage.UpdatePoll(user, poll)
+	session.ID = uuid.NewString()
+	session.OrgID = user.Claims.OrgID
+	session.UserID = user.Claims.Subject
+	session.UserName = user.Claims.Name
+	session.Status = model.PollSessionStatusCreated
+	session.CurrentPollID = ""
+	session.DateCreated = time.Now().UTC()
+	session.DateUpdated = nil
+
+	err = app.checkPollSessionPolls(user, session)
 	if err != nil {
 		return nil, err
 	}
 
-	return updatedPoll, nil
+	return app.storage.CreatePollSession(session)
 }
 
-func (app *Application) deletePoll(user *model.User, id string) error {
-	//get the poll
-	groupMembership, err := app.groups.GetGroupsMembership(user.Token)
+func (app *Application) updatePollSession(user *model.User, id string, session model.PollSession) (*model.PollSession, error) {
+	err := session.Validate()
 	if err != nil {
-		return fmt.Errorf("error getting poll when delete - %s", err)
+		return nil, err
 	}
-	poll, err := app.storage.GetPoll(user, id, true, groupMembership)
+
+	persistedSession, err := app.getPresentedPollSession(user, id, "update")
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if poll == nil {
-		return fmt.Errorf("poll not found")
+	if persistedSession.Status == model.PollSessionStatusEnded {
+		return nil, fmt.Errorf("%w: an ended session can not be updated", model.ErrInvalidPollSession)
 	}
-
-	//check permission
-	err = app.checkPollPermission(user, poll, "delete")
-	if err != nil {
-		return err
+	if len(persistedSession.CurrentPollID) > 0 && !slices.Contains(session.PollIDs, persistedSession.CurrentPollID) {
+		return nil, fmt.Errorf("%w: the live poll can not be removed from the session", model.ErrInvalidPollSession)
 	}
 
-	//delete the poll
-	err = app.storage.DeletePoll(user, id)
+	//the status and the current poll are changed by advancing the session only
+	session.ID = persistedSession.ID
+	session.OrgID = persistedSession.OrgID
+	session.UserID = persistedSession.UserID
+	session.UserName = persistedSession.UserName
+	session.Status = persistedSession.Status
+	session.CurrentPollID = persistedSession.CurrentPollID
+	session.DateCreated = persistedSession.DateCreated
+
+	err = app.checkPollSessionPolls(user, session)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	app.sseServer.NotifyPollForEvent(id, "poll_deleted")
-	app.sseServer.ClosePoll(id)
-
-	return nil
+	return app.storage.UpdatePollSession(session)
 }
 
-func (app *Application) deletePollsWithGroupID(user *model.User, groupID string) error {
-
-	ids, err := app.storage.DeletePollsWithGroupID(nil, groupID) // don't pass orgID (due to wrong value)
+func (app *Application) deletePollSession(user *model.User, id string) error {
+	_, err := app.getPresentedPollSession(user, id, "delete")
 	if err != nil {
 		return err
 	}
 
-	for _, id := range ids {
-		app.sseServer.NotifyPollForEvent(id, "poll_deleted")
-		app.sseServer.ClosePoll(id)
+	err = app.storage.DeletePollSession(user.Claims.OrgID, id)
+	if err != nil {
+		return err
 	}
 
+	app.sseServer.NotifySessionEnded(id)
 	return nil
 }
 
-func (app *Application) startPoll(user *model.User, pollID string) error {
-	poll, err := app.storage.GetPoll(user, pollID, true, nil)
+// advancePollSession makes the next poll of the session live. The previous poll is ended and the next one is started.
+// The session ends when it is advanced past the last poll.
+func (app *Application) advancePollSession(user *model.User, id string) (*model.PollSession, error) {
+	session, err := app.getPresentedPollSession(user, id, "advance")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = app.checkPollPermission(user, poll, "start")
+	status, nextPollID, err := session.Advance()
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return app.changePollSessionStatus(user, session, status, nextPollID)
+}
 
-	if poll != nil {
-		if poll.Status != storage.PollStatusStarted {
-			poll.Status = storage.PollStatusStarted
-			_, err = app.storage.UpdatePoll(user, *poll)
-			if err != nil {
-				return err
-			}
-		}
-	} else {
-		return fmt.Errorf("error app.startPoll() - poll not found: %s", pollID)
+func (app *Application) endPollSession(user *model.User, id string) (*model.PollSession, error) {
+	session, err := app.getPresentedPollSession(user, id, "end")
+	if err != nil {
+		return nil, err
 	}
 
-	app.notifyNotificationsBBForPoll(user, poll, "polls", "poll_started", fmt.Sprintf("Poll '%s' has been started", poll.Question))
-
-	app.sseServer.NotifyPollForEvent(pollID, "poll_started")
-
-	if poll.GroupID != nil {
-		go app.groups.UpdateGroupDateUpdated(*poll.GroupID)
+	status, currentPollID, err := session.End()
+	if err != nil {
+		return nil, err
 	}
-
-	return nil
+	return app.changePollSessionStatus(user, session, status, currentPollID)
 }
 
-func (app *Application) endPoll(user *model.User, pollID string) error {
-	poll, err := app.storage.GetPoll(user, pollID, true, nil)
+// changePollSessionStatus moves the session to the status and the current poll, then ends the previous poll and starts the current one.
+// The session is changed first, so the polls are not started twice by concurrent requests.
+func (app *Application) changePollSessionStatus(user *model.User, session *model.PollSession, status string, currentPollID string) (*model.PollSession, error) {
+	updated, err := app.storage.UpdatePollSessionStatus(*session, status, currentPollID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	err = app.checkPollPermission(user, poll, "end")
-	if err != nil {
-		return err
+	if !updated {
+		return nil, fmt.Errorf("%w: the session has been changed", model.ErrInvalidPollTransition)
 	}
 
-	if poll != nil {
-		if poll.Status != storage.PollStatusTerminated {
-			poll.Status = storage.PollStatusTerminated
-			_, err = app.storage.UpdatePoll(user, *poll)
-			if err != nil {
-				return err
-			}
+	//a poll which has already ended or started is left as it is
+	if len(session.CurrentPollID) > 0 {
+		err = app.endPoll(user, session.CurrentPollID, false)
+		if err != nil && !errors.Is(err, model.ErrInvalidPollTransition) {
+			log.Printf("error app.changePollSessionStatus(%s) - unable to end poll %s - %s", session.ID, session.CurrentPollID, err)
 		}
-	} else {
-		return fmt.Errorf("error app.startPoll() - poll not found: %s", pollID)
-	}
-
-	app.notifyNotificationsBBForPoll(user, poll, "polls", "poll_ended", fmt.Sprintf("Poll '%s' has ended.", poll.Question))
-
-	app.sseServer.NotifyPollForEvent(pollID, "poll_end")
-	app.sseServer.ClosePoll(pollID)
-
-	if poll.GroupID != nil {
-		go app.groups.UpdateGroupDateUpdated(*poll.GroupID)
 	}
-
-	return nil
-}
-
-func (app *Application) notifyNotificationsBBForPoll(user *model.User, poll *model.Poll, topic string, operation string, message string) {
-	subject := "Illinois"
-	if poll.GroupID != nil {
-
-		group, _ := app.groups.GetGroupDetails(user.Token, *poll.GroupID)
-		if group != nil {
-			subject = fmt.Sprintf("Group - %s", group.Title)
+	if len(currentPollID) > 0 {
+		err = app.startPoll(user, currentPollID)
+		if err != nil && !errors.Is(err, model.ErrInvalidPollTransition) {
+			log.Printf("error app.changePollSessionStatus(%s) - unable to start poll %s - %s", session.ID, currentPollID, err)
 		}
+	}
 
-		app.groups.SendGroupNotification(*poll.GroupID, model.GroupNotification{
-			Members: poll.ToMembersList.ToNotificationRecipients(),
-			Sender: &model.Sender{
-				Type: "user",
-				User: &model.UserRef{
-					UserID: user.Claims.Subject,
-					Name:   user.Claims.Name,
-				},
-			},
-			Topic:   &topic,
-			Subject: subject,
-			Body:    message,
-			Data: map[string]string{
-				"group_id":    *poll.GroupID,
-				"type":        "poll",
-				"operation":   operation,
-				"entity_type": "poll",
-				"entity_id":   poll.ID.Hex(),
-				"entity_name": poll.Question,
-			},
-		})
+	now := time.Now().UTC()
+	session.Status = status
+	session.CurrentPollID = currentPollID
+	session.DateUpdated = &now
+	if status == model.PollSessionStatusEnded {
+		session.ActivePin = nil
+		app.sseServer.NotifySessionEnded(session.ID)
 	} else {
-		app.notifications.SendNotification(model.NotificationMessage{
-			Message: model.InnerMessage{
-				AppID:      user.Claims.AppID,
-				OrgID:      user.Claims.OrgID,
-				Recipients: poll.ToMembersList.ToNotificationRecipients(),
-				Sender: &model.Sender{
-					Type: "user",
-					User: &model.UserRef{
-						UserID: user.Claims.Subject,
-						Name:   user.Claims.Name,
-					},
-				},
-				Topic:   &topic,
-				Subject: subject,
-				Body:    message,
-				Data: map[string]string{
-					"type":        "poll",
-					"operation":   operation,
-					"entity_type": "poll",
-					"entity_id":   poll.ID.Hex(),
-					"entity_name": poll.Question,
-				},
-			},
-		})
+		app.sseServer.NotifySessionForEvent(session.ID, "session_poll_changed", currentPollID)
 	}
+	return session, nil
 }
 
-func (app *Application) votePoll(user *model.User, pollID string, vote model.PollVote) error {
-	return app.storage.VotePoll(user, pollID, vote)
-}
-
-func (app *Application) subscribeToPoll(user *model.User, pollID string, resultChan chan map[string]interface{}) error {
-	app.sseServer.RegisterUserForPoll(user.Claims.Subject, pollID, resultChan)
+func (app *Application) subscribeToPollSession(user *model.User, session *model.PollSession, resultChan chan map[string]interface{}) error {
+	//the late participants get the live poll
+	app.sseServer.RegisterUserForSession(user.Claims.Subject, session.ID, session.CurrentPollID, resultChan)
 	return nil
 }
 
-func (app *Application) checkPollPermission(user *model.User, poll *model.Poll, operation string) error {
-	if poll != nil {
-		if user.Claims.Subject != poll.UserID {
-			if poll.GroupID != nil && len(*poll.GroupID) > 0 {
-				group, err := app.groups.GetGroupDetails(user.Token, *poll.GroupID)
-				if err != nil {
-					return err
-				}
-				if group != nil {
-					if !group.IsCurrentUserAdmin(user.Claims.Subject) {
-						return fmt.Errorf("only the creator of a poll or a group admin can %s it", operation)
-					}
-				} else {
-					return fmt.Errorf("only the creator of a poll or a group admin can %s it", operation)
-				}
-			} else {
-				return fmt.Errorf("only the creator of a poll can %s it", operation)
-			}
-		}
-	} else {
-		return fmt.Errorf("poll is nil")
-	}
-	return nil
+func (app *Application) unsubscribeFromPollSession(user *model.User, session *model.PollSession, resultChan chan map[string]interface{}) {
+	app.sseServer.UnregisterUserForSession(session.ID, resultChan)
+	log.Printf("user %s unsubscribed from poll session %s", user.Claims.Subject, session.ID)
 }
 
-// OnCollectionUpdated callback that indicates the reward types collection is changed
-func (app *Application) OnCollectionUpdated(collection string, record map[string]interface{}) {
-	if "polls" == collection && record != nil {
-		data, err := json.Marshal(record)
-		if err != nil {
-			log.Printf("Error on Application.OnCollectionUpdated: %s", err)
-			return
-		}
-
-		if data != nil {
-			var poll model.PollNotification
-			err = json.Unmarshal(data, &poll)
-			if err != nil {
-				log.Printf("Error on Application.OnCollectionUpdated: %s", err)
-				return
-			}
-
-			app.sseServer.NotifyPollUpdate(poll.ID.Hex(), poll)
-		}
+// checkPollSessionAccess checks if the user can follow the session. The presenter always can, the other users can if they
+// can see one of the session polls, by the same members and group filter as a single poll.
+func (app *Application) checkPollSessionAccess(user *model.User, session *model.PollSession) error {
+	if session.UserID == user.Claims.Subject {
+		return nil
 	}
-}
-
-func (app *Application) getSurvey(user *model.User, id string) (*model.Survey, error) {
-	return app.storage.GetSurvey(user, id)
-}
 
-func (app *Application) createSurvey(user *model.User, survey model.Survey, admin bool) (*model.Survey, error) {
-	survey.ID = uuid.NewString()
-	survey.CreatorID = user.Claims.Subject
-	survey.DateCreated = time.Now().UTC()
-	survey.AppID = user.Claims.AppID
-	survey.OrgID = user.Claims.OrgID
-	if !admin {
-		survey.Type = "user"
+	membership, err := app.groups.GetGroupsMembership(user.Token)
+	if err != nil {
+		log.Printf("error app.checkPollSessionAccess() - unable to retrieve user groups - %s", err)
+		return fmt.Errorf("error app.checkPollSessionAccess() - unable to retrieve user groups - %s", err)
 	}
-	return app.storage.CreateSurvey(survey)
-}
 
-func (app *Application) updateSurvey(user *model.User, survey model.Survey, id string, admin bool) error {
-	survey.ID = id
-	if !admin {
-		survey.Type = "user"
+	polls, err := app.storage.GetPolls(user, model.PollsFilter{PollIDs: session.PollIDs}, true, membership)
+	if err != nil {
+		return err
 	}
-	return app.storage.UpdateSurvey(user, survey, admin)
-}
-
-func (app *Application) deleteSurvey(user *model.User, id string, admin bool) error {
-	return app.storage.DeleteSurvey(user, id, admin)
-}
-
-func (app *Application) deleteSurveyResponses(user *model.User, surveyIDs []string, surveyTypes []string, startDate *time.Time, endDate *time.Time) error {
-	return app.storage.DeleteSurveyResponses(user, surveyIDs, surveyTypes, startDate, endDate)
-}
-
-func (app *Application) getSurveyResponse(user *model.User, id string) (*model.SurveyResponse, error) {
-	return app.storage.GetSurveyResponse(user, id)
-}
-
-func (app *Application) getSurveyResponses(user *model.User, surveyIDs []string, surveyTypes []string, startDate *time.Time, endDate *time.Time, limit *int, offset *int) ([]model.SurveyResponse, error) {
-	return app.storage.GetSurveyResponses(user, surveyIDs, surveyTypes, startDate, endDate, limit, offset)
-}
-
-func (app *Application) createSurveyResponse(user *model.User, survey model.Survey) (*model.SurveyResponse, error) {
-	response := model.SurveyResponse{ID: uuid.NewString(), AppID: user.Claims.AppID, OrgID: user.Claims.OrgID,
-		UserID: user.Claims.Subject, DateCreated: time.Now().UTC(), Survey: survey}
-	return app.storage.CreateSurveyResponse(response)
-}
-
-func (app *Application) updateSurveyResponse(user *model.User, id string, survey model.Survey) error {
-	return app.storage.UpdateSurveyResponse(user, id, survey)
-}
-
-func (app *Application) deleteSurveyResponse(user *model.User, id string) error {
-	return app.storage.DeleteSurveyResponse(user, id)
-}
-
-func (app *Application) getAlertContacts(user *model.User) ([]model.AlertContact, error) {
-	return app.storage.GetAlertContacts(user)
-}
-
-func (app *Application) getAlertContact(user *model.User, id string) (*model.AlertContact, error) {
-	return app.storage.GetAlertContact(user, id)
-}
-
-func (app *Application) createAlertContact(user *model.User, alertContact model.AlertContact) (*model.AlertContact, error) {
-	alertContact.ID = uuid.NewString()
-	alertContact.AppID = user.Claims.AppID
-	alertContact.OrgID = user.Claims.OrgID
-	alertContact.DateCreated = time.Now().UTC()
-	return app.storage.CreateAlertContact(alertContact)
+	if len(session.PollIDs) == 0 || len(polls) == 0 {
+		return fmt.Errorf("error app.checkPollSessionAccess(%s) - %w", session.ID, model.ErrPollSessionNotFound)
+	}
+	return nil
 }
 
-func (app *Application) updateAlertContact(user *model.User, id string, alertContact model.AlertContact) error {
-	return app.storage.UpdateAlertContact(user, id, alertContact)
+// getPresentedPollSession gets the session if the user is its presenter
+func (app *Application) getPresentedPollSession(user *model.User, id string, operation string) (*model.PollSession, error) {
+	session, err := app.storage.GetPollSession(user.Claims.OrgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID != user.Claims.Subject {
+		return nil, fmt.Errorf("%w: only the presenter of a session can %s it", model.ErrPollPermission, operation)
+	}
+	return session, nil
 }
 
-func (app *Application) deleteAlertContact(user *model.User, id string) error {
-	return app.storage.DeleteAlertContact(user, id)
+// checkPollSessionPolls checks if the user can manage the polls of the session and they do not belong to another session
+func (app *Application) checkPollSessionPolls(user *model.User, session model.PollSession) error {
+	for _, pollID := range session.PollIDs {
+		poll, err := app.getManagedPoll(user, pollID, "present", false)
+		if err != nil {
+			return err
+		}
+		if len(poll.SessionID) > 0 && poll.SessionID != session.ID {
+			return fmt.Errorf("%w: poll %s belongs to another session", model.ErrInvalidPollSession, pollID)
+		}
+	}
+	return nil
 }
 
 func (app *Application) createSurveyAlert(user *model.User, surveyAlert model.SurveyAlert) error {
@@ -435,11 +1498,12 @@ func (app *Application) createSurveyAlert(user *model.User, surveyAlert model.Su
 
 func (app *Application) getUserData(user *model.User) (*model.UserDataResponse, error) {
 	var wg sync.WaitGroup
-	errChan := make(chan error, 3) // Channel to handle errors
+	errChan := make(chan error, 4) // Channel to handle errors
 	defer close(errChan)
 
 	// Declare response variables
 	var pollsReponse []model.Poll
+	var pollVotes []model.PollUserVotes
 	var survey []model.Survey
 	var surveyResponse []model.SurveyResponse
 
@@ -462,6 +1526,18 @@ func (app *Application) getUserData(user *model.User) (*model.UserDataResponse,
 		}
 	}()
 
+	// Fetch the user votes asynchronously. The votes are returned for the anonymous polls too as they are the user's own data.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		v, err := app.storage.GetUserPollVotes(user, nil)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		pollVotes = v
+	}()
+
 	// Fetch surveys asynchronously
 	wg.Add(1)
 	go func() {
@@ -501,6 +1577,7 @@ func (app *Application) getUserData(user *model.User) (*model.UserDataResponse,
 		Poll:           pollsReponse,
 		Surveys:        survey,
 		SurveyResponse: surveyResponse,
+		PollVotes:      pollVotes,
 	}
 
 	return &userResponse, nil
@@ -14,23 +14,39 @@
 
 package core
 
-import "polls/core/model"
+import (
+	"log"
+	"polls/core/model"
+	"sync"
+	"time"
+)
+
+const (
+	// the max number of events queued for a session subscriber
+	sessionEventsQueueSize = 16
+	// the max time to wait for a poll subscriber to read an event
+	pollEventSendTimeout = 5 * time.Second
+)
 
 // SSEClient struct
 type SSEClient struct {
 	pollID     string
+	sessionID  string
 	userID     string
 	resultChan chan map[string]interface{}
+	events     chan map[string]interface{} // the queued events of a session subscriber
 }
 
 // SSEServer struct
 type SSEServer struct {
-	PollClientsMapping map[string][]SSEClient
+	PollClientsMapping    map[string][]SSEClient
+	SessionClientsMapping map[string][]SSEClient
+	sessionMutex          *sync.Mutex
 }
 
 // NewSSEServer new instance
 func NewSSEServer() *SSEServer {
-	return &SSEServer{PollClientsMapping: map[string][]SSEClient{}}
+	return &SSEServer{PollClientsMapping: map[string][]SSEClient{}, SessionClientsMapping: map[string][]SSEClient{}, sessionMutex: &sync.Mutex{}}
 }
 
 // RegisterUserForPoll registers a user for a poll updates
@@ -89,19 +105,142 @@ func (s *SSEServer) NotifyPollForEvent(pollID string, eventType string) {
 	}
 }
 
-// NotifyPollUpdate notifies all subscribers for changed poll
-func (s *SSEServer) NotifyPollUpdate(pollID string, poll model.PollNotification) {
+// NotifyQuizEnded notifies all subscribers for the correct options and the updated leaderboards of an ended quiz poll.
+// The subscribers are notified in parallel and the slow ones are skipped after pollEventSendTimeout, so the poll stream
+// can be closed once it returns.
+func (s *SSEServer) NotifyQuizEnded(pollID string, correctOptions []int, leaderboards []model.QuizLeaderboard) {
+	event := map[string]interface{}{
+		"poll_id":         pollID,
+		"event_type":      "quiz_ended",
+		"correct_options": correctOptions,
+		"leaderboards":    leaderboards,
+	}
+
+	var wg sync.WaitGroup
+	for _, client := range s.PollClientsMapping[pollID] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case client.resultChan <- event:
+			case <-time.After(pollEventSendTimeout):
+				log.Printf("dropping the quiz_ended event of poll %s for user %s - the stream is not read", pollID, client.userID)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// GetPollUserIDs gets the ids of the users subscribed for a poll updates
+func (s *SSEServer) GetPollUserIDs(pollID string) []string {
+	var userIDs []string
+	if list, ok := s.PollClientsMapping[pollID]; ok {
+		for _, client := range list {
+			userIDs = append(userIDs, client.userID)
+		}
+	}
+	return userIDs
+}
+
+// NotifyPollUpdate notifies all subscribers for changed poll. The results are sent only to the subscribers who can see them.
+func (s *SSEServer) NotifyPollUpdate(pollID string, poll model.PollNotification, voterIDs map[string]bool) {
 	if list, ok := s.PollClientsMapping[pollID]; ok {
 		if len(list) > 0 {
 			for _, client := range list {
 				go func() {
-					client.resultChan <- map[string]interface{}{
+					result := poll.ToPollResult(client.userID, voterIDs[client.userID])
+					event := map[string]interface{}{
 						"poll_id":    pollID,
 						"event_type": "poll_updated",
-						"result":     poll.ToPollResult(client.userID).Results,
+						"result":     result.Results,
+					}
+					if result.ResultsHidden {
+						event["results_hidden"] = true
+					}
+					if result.Runoff != nil {
+						event["runoff"] = result.Runoff
+					}
+					if result.TextResults != nil {
+						event["text_results"] = result.TextResults
 					}
+					client.resultChan <- event
 				}()
 			}
 		}
 	}
 }
+
+// RegisterUserForSession registers a user for a poll session updates and sends the live poll. The events of a session
+// subscriber are queued and sent in order by its own goroutine, so the notifications never wait for the subscribers.
+func (s *SSEServer) RegisterUserForSession(userID, sessionID string, currentPollID string, resultChan chan map[string]interface{}) {
+	s.sessionMutex.Lock()
+	defer s.sessionMutex.Unlock()
+
+	events := make(chan map[string]interface{}, sessionEventsQueueSize)
+	go func() {
+		for event := range events {
+			resultChan <- event
+		}
+		close(resultChan)
+	}()
+
+	client := SSEClient{sessionID: sessionID, userID: userID, resultChan: resultChan, events: events}
+	client.queueSessionEvent(sessionID, "session_poll_changed", currentPollID)
+	s.SessionClientsMapping[sessionID] = append(s.SessionClientsMapping[sessionID], client)
+}
+
+// NotifySessionForEvent notifies all subscribers of a poll session for the event and the live poll
+func (s *SSEServer) NotifySessionForEvent(sessionID string, eventType string, pollID string) {
+	s.sessionMutex.Lock()
+	defer s.sessionMutex.Unlock()
+
+	for _, client := range s.SessionClientsMapping[sessionID] {
+		client.queueSessionEvent(sessionID, eventType, pollID)
+	}
+}
+
+// UnregisterUserForSession unregisters the subscriber of a poll session with the stream. The queued events are still
+// sent and the stream is closed afterwards, so the subscriber must read it until it is closed.
+func (s *SSEServer) UnregisterUserForSession(sessionID string, resultChan chan map[string]interface{}) {
+	s.sessionMutex.Lock()
+	defer s.sessionMutex.Unlock()
+
+	clients := s.SessionClientsMapping[sessionID]
+	for i, client := range clients {
+		if client.resultChan == resultChan {
+			close(client.events)
+			clients = append(clients[:i:i], clients[i+1:]...)
+			break
+		}
+	}
+	if len(clients) > 0 {
+		s.SessionClientsMapping[sessionID] = clients
+	} else {
+		delete(s.SessionClientsMapping, sessionID)
+	}
+}
+
+// NotifySessionEnded notifies all subscribers of a poll session that it has ended and closes their streams
+func (s *SSEServer) NotifySessionEnded(sessionID string) {
+	s.sessionMutex.Lock()
+	defer s.sessionMutex.Unlock()
+
+	for _, client := range s.SessionClientsMapping[sessionID] {
+		client.queueSessionEvent(sessionID, "session_ended", "")
+		close(client.events)
+	}
+	delete(s.SessionClientsMapping, sessionID)
+}
+
+// queueSessionEvent queues the event for the subscriber. The event is dropped if the subscriber does not read its stream.
+func (c SSEClient) queueSessionEvent(sessionID string, eventType string, pollID string) {
+	select {
+	case c.events <- map[string]interface{}{
+		"session_id": sessionID,
+		"event_type": eventType,
+		"poll_id":    pollID,
+	}:
+	default:
+		log.Printf("dropping %s event of poll session %s for user %s", eventType, sessionID, c.userID)
+	}
+}
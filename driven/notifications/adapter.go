@@ -30,6 +30,13 @@ func (a *Adapter) SendNotification(notification model.NotificationMessage) {
 // SendNotification sends notification to a user
 func (a *Adapter) sendNotification(notification model.NotificationMessage) {
 	if notification.Message.Subject != "" && notification.Message.Body != "" {
+		if notification.Message.AppID == "" {
+			notification.Message.AppID = a.appID
+		}
+		if notification.Message.OrgID == "" {
+			notification.Message.OrgID = a.orgID
+		}
+
 		url := fmt.Sprintf("%s/api/int/v2/message", a.baseURL)
 
 		bodyBytes, err := json.Marshal(notification)
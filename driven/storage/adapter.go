@@ -18,9 +18,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math/rand"
 	"polls/core/model"
 	"polls/driven/groups"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
@@ -35,16 +38,22 @@ import (
 
 const (
 	// PollStatusCreated status created
-	PollStatusCreated = "created"
+	PollStatusCreated = model.PollStatusCreated
 
 	// PollStatusStarted status started
-	PollStatusStarted = "started"
+	PollStatusStarted = model.PollStatusStarted
+
+	// PollStatusPaused status paused
+	PollStatusPaused = model.PollStatusPaused
 
 	// PollStatusTerminated status terminated
-	PollStatusTerminated = "terminated"
+	PollStatusTerminated = model.PollStatusTerminated
 
 	settingsKey   = "stadium"
 	eventInterval = 100 * time.Millisecond
+
+	// the number of random PINs tried when they collide with PINs reserved in the meantime
+	pollPinAllocationAttempts = 5
 )
 
 // TransactionContext wraps mongo.SessionContext for use by external packages
@@ -66,6 +75,26 @@ func (sa *Adapter) Start() error {
 	}
 
 	err = sa.applyMultiTenancy()
+	if err != nil {
+		return err
+	}
+
+	err = sa.backfillPollCounters()
+	if err != nil {
+		return err
+	}
+
+	err = sa.migratePollVotes()
+	if err != nil {
+		return err
+	}
+
+	err = sa.reserveActivePollPins()
+	if err != nil {
+		return err
+	}
+
+	err = sa.backfillSurveySearchTexts()
 	return err
 }
 
@@ -99,18 +128,37 @@ func (sa *Adapter) GetPolls(user *model.User, filter model.PollsFilter, filterBy
 		mongoFilter = append(mongoFilter, primitive.E{Key: "_id", Value: bson.M{"$in": reconstructedIDs}})
 	}
 
+	votedPollIDs := []primitive.ObjectID{}
+	if filter.RespondedPolls != nil && *filter.RespondedPolls == true {
+		ids, err := sa.getVotedPollIDs(user)
+		if err != nil {
+			return nil, err
+		}
+		votedPollIDs = ids
+	}
+
 	if filter.MyPolls != nil && *filter.MyPolls == true && filter.RespondedPolls != nil && *filter.RespondedPolls == true {
 		mongoFilter = append(mongoFilter, primitive.E{Key: "$or", Value: []primitive.M{
 			{"poll.userid": user.Claims.Subject},
-			{"responses.userid": user.Claims.Subject},
+			{"poll.co_owners": user.Claims.Subject},
+			{"_id": bson.M{"$in": votedPollIDs}},
 		}})
 	} else {
 		if filter.MyPolls != nil && *filter.MyPolls == true {
-			mongoFilter = append(mongoFilter, primitive.E{Key: "poll.userid", Value: user.Claims.Subject})
+			// wrapped in $and as the filter may get another $or condition
+			mongoFilter = append(mongoFilter, primitive.E{Key: "$and", Value: []primitive.M{
+				{"$or": []primitive.M{
+					{"poll.userid": user.Claims.Subject},
+					{"poll.co_owners": user.Claims.Subject},
+				}},
+			}})
 		}
 
 		if filter.RespondedPolls != nil && *filter.RespondedPolls == true {
-			mongoFilter = append(mongoFilter, primitive.E{Key: "responses.userid", Value: user.Claims.Subject})
+			// wrapped in $and as the filter may already contain an _id condition
+			mongoFilter = append(mongoFilter, primitive.E{Key: "$and", Value: []primitive.M{
+				{"_id": bson.M{"$in": votedPollIDs}},
+			}})
 		}
 	}
 
@@ -126,6 +174,19 @@ func (sa *Adapter) GetPolls(user *model.User, filter model.PollsFilter, filterBy
 		mongoFilter = append(mongoFilter, primitive.E{Key: "poll.status", Value: bson.M{"$in": filter.Statuses}})
 	}
 
+	if len(filter.UserIDs) > 0 {
+		mongoFilter = append(mongoFilter, primitive.E{Key: "poll.userid", Value: bson.M{"$in": filter.UserIDs}})
+	}
+
+	if len(filter.Stadiums) > 0 {
+		mongoFilter = append(mongoFilter, primitive.E{Key: "poll.stadium", Value: bson.M{"$in": filter.Stadiums}})
+	}
+
+	search := strings.TrimSpace(filter.Search)
+	if len(search) > 0 {
+		mongoFilter = append(mongoFilter, primitive.E{Key: "$text", Value: bson.M{"$search": search}})
+	}
+
 	if filterByToMembers {
 		var innerFilter primitive.M
 		if membership != nil && len(membership.GroupIDsAsAdmin) > 0 {
@@ -140,18 +201,51 @@ func (sa *Adapter) GetPolls(user *model.User, filter model.PollsFilter, filterBy
 		mongoFilter = append(mongoFilter, primitive.E{Key: "$or", Value: []primitive.M{
 			primitive.M{"poll.to_members": primitive.Null{}},
 			primitive.M{"poll.to_members": primitive.M{"$exists": true, "$size": 0}},
-			primitive.M{"poll.user_id": user.Claims.Subject},
+			primitive.M{"poll.userid": user.Claims.Subject},
+			primitive.M{"poll.co_owners": user.Claims.Subject},
 			innerFilter,
 		}})
 	}
 
+	var cursor *model.PageCursor
+	if filter.Cursor != nil {
+		if len(search) > 0 {
+			return nil, fmt.Errorf("%w: the search results are not paginated by cursor", model.ErrInvalidCursor)
+		}
+
+		pageCursor, err := model.DecodePageCursor(*filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cursor = pageCursor
+	}
+	if cursor != nil {
+		cursorID, err := primitive.ObjectIDFromHex(cursor.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", model.ErrInvalidCursor, err)
+		}
+
+		//the polls created before the cursor, the status changes between the pages
+		mongoFilter = append(mongoFilter, primitive.E{Key: "$and", Value: []primitive.M{
+			{"_id": bson.M{"$lt": cursorID}},
+		}})
+	}
+
 	findOptions := options.Find()
-	findOptions.SetSort(bson.D{{Key: "poll.status", Value: 1}, {Key: "_id", Value: -1}})
+	if len(search) > 0 {
+		findOptions.SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}})
+		findOptions.SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "_id", Value: -1}})
+	} else if filter.Cursor != nil {
+		//the pages are in the creation order, newest first, as the ids are immutable and increasing
+		findOptions.SetSort(bson.D{{Key: "_id", Value: -1}})
+	} else {
+		findOptions.SetSort(bson.D{{Key: "poll.status", Value: 1}, {Key: "_id", Value: -1}})
+	}
 
 	if filter.Limit != nil {
 		findOptions.SetLimit(*filter.Limit)
 	}
-	if filter.Offset != nil {
+	if filter.Offset != nil && filter.Cursor == nil {
 		findOptions.SetSkip(*filter.Offset)
 	}
 
@@ -161,19 +255,67 @@ func (sa *Adapter) GetPolls(user *model.User, filter model.PollsFilter, filterBy
 		return nil, err
 	}
 
+	if user != nil {
+		err = sa.setUserVotes(user, list)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return list, nil
 }
 
 // DeletePollsWithAccountIDs Deletes polls
 func (sa Adapter) DeletePollsWithAccountIDs(orgID string, accountsIDs []string) error {
-	filter := bson.D{
-		primitive.E{Key: "org_id", Value: orgID},
-		primitive.E{Key: "poll.userid", Value: bson.M{"$in": accountsIDs}},
-	}
+	err := sa.PerformTransaction(func(ctx TransactionContext) error {
+		filter := bson.D{
+			primitive.E{Key: "org_id", Value: orgID},
+			primitive.E{Key: "poll.userid", Value: bson.M{"$in": accountsIDs}},
+		}
+
+		var polls []model.Poll
+		err := sa.db.polls.FindWithContext(ctx, filter, &polls, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, "user", nil, err)
+		}
+
+		_, err = sa.db.polls.DeleteManyWithContext(ctx, filter, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionDelete, "user", nil, err)
+		}
 
-	_, err := sa.db.polls.DeleteMany(filter, nil)
+		pollIDs := make([]primitive.ObjectID, len(polls))
+		for i, poll := range polls {
+			pollIDs[i] = poll.ID
+		}
+		_, err = sa.db.pollVotes.DeleteManyWithContext(ctx, bson.D{primitive.E{Key: "poll_id", Value: bson.M{"$in": pollIDs}}}, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionDelete, "poll_votes", nil, err)
+		}
+
+		// the scores of the users in the deleted quiz polls
+		hexPollIDs := make([]string, len(polls))
+		for i, poll := range polls {
+			hexPollIDs[i] = poll.ID.Hex()
+		}
+		_, err = sa.db.quizScores.DeleteManyWithContext(ctx, bson.D{primitive.E{Key: "poll_id", Value: bson.M{"$in": hexPollIDs}}}, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionDelete, "quiz_scores", nil, err)
+		}
+
+		// the users do not co-own the polls of the other users anymore
+		coOwnersFilter := bson.D{
+			primitive.E{Key: "org_id", Value: orgID},
+			primitive.E{Key: "poll.co_owners", Value: bson.M{"$in": accountsIDs}},
+		}
+		_, err = sa.db.polls.UpdateManyWithContext(ctx, coOwnersFilter, bson.M{"$pull": bson.M{"poll.co_owners": bson.M{"$in": accountsIDs}}}, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, "poll_co_owners", nil, err)
+		}
+		return nil
+	})
 	if err != nil {
-		return errors.WrapErrorAction(logutils.ActionDelete, "user", nil, err)
+		return errors.WrapErrorAction(logutils.ActionDelete, "user_polls", nil, err)
 	}
 	return nil
 }
@@ -201,10 +343,22 @@ func (sa Adapter) DeletePollsWithGroupID(orgID *string, groupID string) ([]strin
 			return errors.WrapErrorAction(logutils.ActionDelete, "group_polls", nil, err)
 		}
 
-		for _, poll := range polls {
+		objIDs := make([]primitive.ObjectID, len(polls))
+		for i, poll := range polls {
+			objIDs[i] = poll.ID
 			pollIDs = append(pollIDs, poll.ID.Hex())
 		}
 
+		_, err = sa.db.pollVotes.DeleteManyWithContext(ctx, bson.D{primitive.E{Key: "poll_id", Value: bson.M{"$in": objIDs}}}, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionDelete, "poll_votes", nil, err)
+		}
+
+		_, err = sa.db.quizScores.DeleteManyWithContext(ctx, bson.D{primitive.E{Key: "poll_id", Value: bson.M{"$in": pollIDs}}}, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionDelete, "quiz_scores", nil, err)
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -237,45 +391,215 @@ func (sa *Adapter) GetPoll(user *model.User, id string, filterByToMembers bool,
 			filter = append(filter, primitive.E{Key: "$or", Value: []primitive.M{
 				{"poll.to_members": primitive.Null{}},
 				{"poll.to_members": primitive.M{"$exists": true, "$size": 0}},
-				{"poll.user_id": user.Claims.Subject},
+				{"poll.userid": user.Claims.Subject},
+				{"poll.co_owners": user.Claims.Subject},
 				innerFilter,
 			}})
 		}
 
 		var poll model.Poll
 		err := sa.db.polls.FindOne(filter, &poll, &options.FindOneOptions{})
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("error storage.Adapter.GetPoll(%s) - %w", id, model.ErrPollNotFound)
+		}
+		if err != nil {
+			fmt.Printf("error storage.Adapter.GetPoll(%s) - %s", id, err)
+			return nil, fmt.Errorf("error storage.Adapter.GetPoll(%s) - %s", id, err)
+		}
+
+		polls := []model.Poll{poll}
+		err = sa.setUserVotes(user, polls)
 		if err != nil {
 			fmt.Printf("error storage.Adapter.GetPoll(%s) - %s", id, err)
 			return nil, fmt.Errorf("error storage.Adapter.GetPoll(%s) - %s", id, err)
 		}
 
-		return &poll, nil
+		return &polls[0], nil
 	}
 
 	fmt.Printf("error storage.Adapter.GetPoll(%s) - unable to construct obj id", id)
-	return nil, fmt.Errorf("error storage.Adapter.GetPoll(%s) - unable to construct obj id", id)
+	return nil, fmt.Errorf("error storage.Adapter.GetPoll(%s) - unable to construct obj id: %w", id, model.ErrPollNotFound)
+}
+
+// GetPollByPin retrieves the active poll with the PIN. Returns nil if there is no such poll.
+func (sa *Adapter) GetPollByPin(user *model.User, pin int, membership *groups.GroupMembership) (*model.Poll, error) {
+	filter := bson.D{
+		primitive.E{Key: "org_id", Value: user.Claims.OrgID},
+		primitive.E{Key: "active_pin", Value: pin},
+	}
+
+	var innerFilter primitive.M
+	if membership != nil && len(membership.GroupIDsAsAdmin) > 0 {
+		innerFilter = primitive.M{"$or": []primitive.M{
+			{"poll.group_id": bson.M{"$in": membership.GroupIDsAsAdmin}},
+			{"poll.to_members.user_id": user.Claims.Subject},
+		}}
+	} else {
+		innerFilter = primitive.M{"poll.to_members.user_id": user.Claims.Subject}
+	}
+	filter = append(filter, primitive.E{Key: "$or", Value: []primitive.M{
+		{"poll.to_members": primitive.Null{}},
+		{"poll.to_members": primitive.M{"$exists": true, "$size": 0}},
+		{"poll.userid": user.Claims.Subject},
+		{"poll.co_owners": user.Claims.Subject},
+		innerFilter,
+	}})
+
+	var poll model.Poll
+	err := sa.db.polls.FindOne(filter, &poll, nil)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetPollByPin(%d) - %s", pin, err)
+		return nil, fmt.Errorf("error storage.Adapter.GetPollByPin(%d) - %s", pin, err)
+	}
+
+	polls := []model.Poll{poll}
+	err = sa.setUserVotes(user, polls)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetPollByPin(%d) - %s", pin, err)
+		return nil, fmt.Errorf("error storage.Adapter.GetPollByPin(%d) - %s", pin, err)
+	}
+
+	return &polls[0], nil
 }
 
 // CreatePoll creates a poll
 func (sa *Adapter) CreatePoll(user *model.User, poll model.Poll) (*model.Poll, error) {
-	now := time.Now()
 	poll.OrgID = user.Claims.OrgID
-	poll.ID = primitive.NewObjectID()
 	poll.UserID = user.Claims.Subject
 	poll.UserName = user.Claims.Name
-	poll.DateCreated = now
-	poll.DateUpdated = now
 
-	_, err := sa.db.polls.InsertOne(poll)
+	err := sa.insertPoll(&poll)
 	if err != nil {
 		fmt.Printf("error storage.Adapter.CreatePoll(%s) - %s", poll.ID, err)
-		return nil, fmt.Errorf("error storage.Adapter.CreatePoll(%s) - %s", poll.ID, err)
+		return nil, fmt.Errorf("error storage.Adapter.CreatePoll(%s) - %w", poll.ID, err)
 	}
 
 	return &poll, nil
 }
 
-// UpdatePoll updates a poll
+// CreatePollInstance creates the next instance of a recurring poll and moves the recurring poll to its next instance time
+// in one transaction, only if the next instance time is still the one of the recurring poll. The series is complete when
+// the next time is nil. The instance keeps the organization and the owner of the series.
+// Returns nil if the instance has already been created by someone else.
+func (sa *Adapter) CreatePollInstance(series model.Poll, instance model.Poll, nextInstanceAt *time.Time, instancesCount int) (*model.Poll, error) {
+	initPoll(&instance)
+
+	created := false
+	err := sa.reservePollPin(&instance, func() error {
+		return sa.PerformTransaction(func(ctx TransactionContext) error {
+			created = false
+			updated, err := sa.updatePollRecurrence(ctx, series, nextInstanceAt, instancesCount)
+			if err != nil || !updated {
+				return err
+			}
+
+			_, err = sa.db.polls.InsertOneWithContext(ctx, instance)
+			if err != nil {
+				return err
+			}
+			created = true
+			return nil
+		})
+	})
+	if err != nil {
+		fmt.Printf("error storage.Adapter.CreatePollInstance(%s) - %s", series.ID.Hex(), err)
+		return nil, fmt.Errorf("error storage.Adapter.CreatePollInstance(%s) - %w", series.ID.Hex(), err)
+	}
+	if !created {
+		return nil, nil
+	}
+
+	return &instance, nil
+}
+
+func (sa *Adapter) insertPoll(poll *model.Poll) error {
+	initPoll(poll)
+
+	return sa.reservePollPin(poll, func() error {
+		_, err := sa.db.polls.InsertOne(poll)
+		return err
+	})
+}
+
+// initPoll sets the id, the dates and the empty counters of a new poll
+func initPoll(poll *model.Poll) {
+	now := time.Now()
+	poll.ID = primitive.NewObjectID()
+	poll.DateCreated = now
+	poll.DateUpdated = now
+	poll.Counters = &model.PollCounters{Options: map[string]int{}}
+	poll.Responses = nil // the votes are stored in the poll votes collection
+}
+
+// reservePollPin reserves the poll PIN among the active polls of the organization while the poll is stored.
+// The PIN uniqueness is guaranteed by the unique index on the active PIN. An allocated PIN is retried on collisions.
+func (sa *Adapter) reservePollPin(poll *model.Poll, store func() error) error {
+	for attempt := 1; ; attempt++ {
+		if poll.AutoPin {
+			pin, err := sa.allocateActivePin(sa.db.polls, poll.OrgID)
+			if err != nil {
+				return err
+			}
+			poll.Pin = pin
+		}
+
+		poll.ActivePin = nil
+		if poll.Status != PollStatusTerminated && poll.Pin > 0 {
+			pin := poll.Pin
+			poll.ActivePin = &pin
+		}
+
+		err := store()
+		if err == nil {
+			return nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+		if !poll.AutoPin || attempt >= pollPinAllocationAttempts {
+			return fmt.Errorf("%w: %d", model.ErrPollPinInUse, poll.Pin)
+		}
+	}
+}
+
+// allocateActivePin picks a random PIN which is not used by the active polls or sessions of the organization in the collection
+func (sa *Adapter) allocateActivePin(collection *collectionWrapper, orgID string) (int, error) {
+	filter := bson.D{
+		primitive.E{Key: "org_id", Value: orgID},
+	}
+	values, err := collection.Distinct("active_pin", filter)
+	if err != nil {
+		return 0, err
+	}
+
+	used := map[int64]bool{}
+	for _, value := range values {
+		switch pin := value.(type) {
+		case int32:
+			used[int64(pin)] = true
+		case int64:
+			used[pin] = true
+		}
+	}
+
+	free := []int{}
+	for pin := 1; pin <= model.MaxPollPin; pin++ {
+		if !used[int64(pin)] {
+			free = append(free, pin)
+		}
+	}
+	if len(free) == 0 {
+		return 0, fmt.Errorf("%w: all PINs are used", model.ErrPollPinInUse)
+	}
+	return free[rand.Intn(len(free))], nil
+}
+
+// UpdatePoll updates a poll. The status is changed by UpdatePollStatus only, the poll is updated only while it still has
+// the status of the passed poll. The poll type, scale and options can not be changed once the poll has votes. ErrPollChanged
+// is returned if the status has been changed or the poll has got votes in the meantime.
 func (sa *Adapter) UpdatePoll(user *model.User, poll model.Poll) (*model.Poll, error) {
 
 	if len(poll.ID) > 0 {
@@ -283,10 +607,16 @@ func (sa *Adapter) UpdatePoll(user *model.User, poll model.Poll) (*model.Poll, e
 		filter := bson.D{
 			primitive.E{Key: "org_id", Value: user.Claims.OrgID},
 			primitive.E{Key: "_id", Value: poll.ID},
+			primitive.E{Key: "poll.status", Value: poll.Status},
+			primitive.E{Key: "$or", Value: []bson.D{
+				{primitive.E{Key: "counters.unique_voters", Value: bson.M{"$not": bson.M{"$gt": 0}}}},
+				pollAnswerFormatFilter(poll.PollData),
+			}},
 		}
 
-		update := bson.D{
-			primitive.E{Key: "$set", Value: bson.D{
+		poll.OrgID = user.Claims.OrgID
+		err := sa.reservePollPin(&poll, func() error {
+			set := bson.D{
 				primitive.E{Key: "poll.date_updated", Value: poll.DateUpdated},
 				primitive.E{Key: "poll.to_members", Value: poll.ToMembersList},
 				primitive.E{Key: "poll.pin", Value: poll.Pin},
@@ -295,164 +625,1123 @@ func (sa *Adapter) UpdatePoll(user *model.User, poll model.Poll) (*model.Poll, e
 				primitive.E{Key: "poll.group_id", Value: poll.GroupID},
 				primitive.E{Key: "poll.multi_choice", Value: poll.MultiChoice},
 				primitive.E{Key: "poll.repeat", Value: poll.Repeat},
+				primitive.E{Key: "poll.allow_vote_change", Value: poll.AllowVoteChange},
 				primitive.E{Key: "poll.show_results", Value: poll.ShowResults},
+				primitive.E{Key: "poll.results_visibility", Value: poll.ResultsVisibility},
 				primitive.E{Key: "poll.stadium", Value: poll.Stadium},
 				primitive.E{Key: "poll.geo_fence", Value: poll.Geo},
-				primitive.E{Key: "poll.status", Value: poll.Status},
-			}},
-		}
+				primitive.E{Key: "poll.geo_region", Value: poll.GeoRegion},
+				primitive.E{Key: "poll.anonymous", Value: poll.Anonymous},
+				primitive.E{Key: "poll.poll_type", Value: poll.PollType},
+				primitive.E{Key: "poll.scale", Value: poll.Scale},
+				primitive.E{Key: "poll.start_at", Value: poll.StartAt},
+				primitive.E{Key: "poll.end_at", Value: poll.EndAt},
+				primitive.E{Key: "poll.auto_close", Value: poll.AutoClose},
+				primitive.E{Key: "poll.quiz", Value: poll.Quiz},
+				primitive.E{Key: "poll.session_id", Value: poll.SessionID},
+				primitive.E{Key: "poll.default_locale", Value: poll.DefaultLocale},
+				primitive.E{Key: "poll.localizations", Value: poll.Localizations},
+				primitive.E{Key: "poll.recurrence", Value: poll.Recurrence},
+			}
+			unset := bson.D{}
+			if poll.ActivePin != nil {
+				set = append(set, primitive.E{Key: "active_pin", Value: *poll.ActivePin})
+			} else {
+				unset = append(unset, primitive.E{Key: "active_pin", Value: ""})
+			}
+			if poll.NextInstanceAt != nil {
+				set = append(set, primitive.E{Key: "next_instance_at", Value: *poll.NextInstanceAt})
+			} else {
+				unset = append(unset, primitive.E{Key: "next_instance_at", Value: ""})
+			}
+			update := bson.D{primitive.E{Key: "$set", Value: set}}
+			if len(unset) > 0 {
+				update = append(update, primitive.E{Key: "$unset", Value: unset})
+			}
 
-		_, err := sa.db.polls.UpdateOne(filter, update, nil)
+			res, err := sa.db.polls.UpdateOne(filter, update, nil)
+			if err != nil {
+				return err
+			}
+			if res.MatchedCount == 0 {
+				return model.ErrPollChanged
+			}
+			return nil
+		})
 		if err != nil {
 			fmt.Printf("error storage.Adapter.UpdatePoll(%s) - %s", poll.ID, err)
-			return nil, fmt.Errorf("error storage.Adapter.UpdatePoll(%s) - %s", poll.ID, err)
+			return nil, fmt.Errorf("error storage.Adapter.UpdatePoll(%s) - %w", poll.ID, err)
 		}
 	}
 
 	return &poll, nil
 }
 
-// StartPoll starts an existing poll
-func (sa *Adapter) StartPoll(user *model.User, pollID string) error {
+// pollAnswerFormatFilter matches the polls which answers are interpreted the same way as the answers of the poll,
+// see PollData.HasSameAnswerFormat
+func pollAnswerFormatFilter(poll model.PollData) bson.D {
+	var pollType interface{} = poll.PollType
+	if len(poll.PollType) == 0 {
+		pollType = nil // not stored
+	}
+	filter := bson.D{
+		primitive.E{Key: "poll.poll_type", Value: pollType},
+		primitive.E{Key: "poll.scale", Value: poll.Scale},
+	}
+	if !poll.IsScale() && !poll.IsOpenText() {
+		filter = append(filter, primitive.E{Key: "poll.options", Value: poll.Options})
+	}
+	return filter
+}
 
-	poll, err := sa.GetPoll(user, pollID, true, nil)
-	if err != nil {
-		fmt.Printf("error storage.Adapter.StartPoll(%s) - %s", pollID, err)
-		return fmt.Errorf("error storage.Adapter.StartPoll(%s) - %s", pollID, err)
+// AddPollCoOwner adds a co-owner to a poll. Adding an existing co-owner does nothing.
+func (sa *Adapter) AddPollCoOwner(orgID string, pollID primitive.ObjectID, userID string) error {
+	filter := bson.D{
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: pollID},
+	}
+	update := bson.D{
+		primitive.E{Key: "$addToSet", Value: bson.D{
+			primitive.E{Key: "poll.co_owners", Value: userID},
+		}},
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "poll.date_updated", Value: time.Now().UTC()},
+		}},
 	}
 
-	if poll != nil {
-		if poll.Status != PollStatusStarted {
-			poll.Status = PollStatusStarted
-			_, err = sa.UpdatePoll(user, *poll)
-			if err != nil {
-				fmt.Printf("error storage.Adapter.StartPoll(%s) - %s", pollID, err)
-				return fmt.Errorf("error storage.Adapter.StartPoll(%s) - %s", pollID, err)
-			}
-		}
-	} else {
-		return fmt.Errorf("error storage.Adapter.EndPoll(%s) - poll not found: %s", pollID, err)
+	_, err := sa.db.polls.UpdateOne(filter, update, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.AddPollCoOwner(%s) - %s", pollID.Hex(), err)
+		return fmt.Errorf("error storage.Adapter.AddPollCoOwner(%s) - %s", pollID.Hex(), err)
 	}
 
 	return nil
 }
 
-// EndPoll ends an existing poll
-func (sa *Adapter) EndPoll(user *model.User, pollID string) error {
-	poll, err := sa.GetPoll(user, pollID, true, nil)
-	if err != nil {
-		fmt.Printf("error storage.Adapter.EndPoll(%s) - %s", pollID, err)
-		return fmt.Errorf("error storage.Adapter.EndPoll(%s) - %s", pollID, err)
+// RemovePollCoOwner removes a co-owner from a poll. Removing a user who is not a co-owner does nothing.
+func (sa *Adapter) RemovePollCoOwner(orgID string, pollID primitive.ObjectID, userID string) error {
+	filter := bson.D{
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "_id", Value: pollID},
+	}
+	update := bson.D{
+		primitive.E{Key: "$pull", Value: bson.D{
+			primitive.E{Key: "poll.co_owners", Value: userID},
+		}},
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "poll.date_updated", Value: time.Now().UTC()},
+		}},
 	}
 
-	if poll != nil {
-		if poll.Status != PollStatusTerminated {
-			poll.Status = PollStatusTerminated
-			_, err = sa.UpdatePoll(user, *poll)
-			if err != nil {
-				fmt.Printf("error storage.Adapter.EndPoll(%s) - %s", pollID, err)
-				return fmt.Errorf("error storage.Adapter.EndPoll(%s) - %s", pollID, err)
-			}
-		}
-	} else {
-		return fmt.Errorf("error storage.Adapter.EndPoll(%s) - poll not found: %s", pollID, err)
+	_, err := sa.db.polls.UpdateOne(filter, update, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.RemovePollCoOwner(%s) - %s", pollID.Hex(), err)
+		return fmt.Errorf("error storage.Adapter.RemovePollCoOwner(%s) - %s", pollID.Hex(), err)
 	}
 
 	return nil
 }
 
-// DeletePoll deletes a poll
-func (sa *Adapter) DeletePoll(user *model.User, id string) error {
-	if objID, err := primitive.ObjectIDFromHex(id); err == nil {
-		filter := bson.D{
-			primitive.E{Key: "org_id", Value: user.Claims.OrgID},
-			primitive.E{Key: "_id", Value: objID},
-		}
-		_, err := sa.db.polls.DeleteOne(filter, nil)
-		if err != nil {
-			fmt.Printf("error storage.Adapter.DeletePoll(): error while delete poll (%s) - %s", id, err)
-			return fmt.Errorf("error storage.Adapter.DeletePoll(): error while delete poll (%s) - %s", id, err)
-		}
+// GetScheduledPollsToStart gets the created polls which start time has come. Polls which end time has come too are skipped.
+func (sa *Adapter) GetScheduledPollsToStart(now time.Time) ([]model.Poll, error) {
+	filter := bson.D{
+		primitive.E{Key: "poll.status", Value: PollStatusCreated},
+		primitive.E{Key: "poll.start_at", Value: bson.M{"$lte": now}},
+		primitive.E{Key: "$or", Value: []bson.M{
+			{"poll.end_at": nil},
+			{"poll.end_at": bson.M{"$gt": now}},
+		}},
+	}
 
+	var results []model.Poll
+	err := sa.db.polls.Find(filter, &results, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetScheduledPollsToStart - %s", err)
+		return nil, fmt.Errorf("error storage.Adapter.GetScheduledPollsToStart - %s", err)
 	}
-	return nil
 
+	return results, nil
 }
 
-// VotePoll votes a poll
-func (sa *Adapter) VotePoll(user *model.User, pollID string, vote model.PollVote) error {
-
-	if objID, err := primitive.ObjectIDFromHex(pollID); err == nil {
-		now := time.Now().UTC()
-		vote.Created = now
-
-		filter := bson.D{
-			primitive.E{Key: "_id", Value: objID},
-		}
-
-		update := bson.D{
-			primitive.E{Key: "$set", Value: bson.D{
-				primitive.E{Key: "poll.date_updated", Value: now},
-			}},
-			primitive.E{Key: "$push", Value: bson.D{
-				primitive.E{Key: "responses", Value: vote},
-			}},
-		}
+// GetScheduledPollsToEnd gets the not terminated polls which end time has come
+func (sa *Adapter) GetScheduledPollsToEnd(now time.Time) ([]model.Poll, error) {
+	filter := bson.D{
+		primitive.E{Key: "poll.status", Value: bson.M{"$in": []string{PollStatusCreated, PollStatusStarted, PollStatusPaused}}},
+		primitive.E{Key: "poll.end_at", Value: bson.M{"$lte": now}},
+	}
 
-		_, err := sa.db.polls.UpdateOne(filter, update, nil)
-		if err != nil {
-			fmt.Printf("error storage.Adapter.VotePoll(%s) - %s", pollID, err)
-			return fmt.Errorf("error storage.Adapter.VotePoll(%s) - %s", pollID, err)
-		}
+	var results []model.Poll
+	err := sa.db.polls.Find(filter, &results, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetScheduledPollsToEnd - %s", err)
+		return nil, fmt.Errorf("error storage.Adapter.GetScheduledPollsToEnd - %s", err)
 	}
-	return nil
-}
 
-// SetListener sets the upper layer listener for sending collection changed callbacks
-func (sa *Adapter) SetListener(listener CollectionListener) {
-	sa.db.listener = listener
+	return results, nil
 }
 
-// Event
+// GetNextPollScheduleTime gets the earliest pending scheduled start or end time. Returns nil if there is nothing scheduled.
+func (sa *Adapter) GetNextPollScheduleTime() (*time.Time, error) {
+	var next *time.Time
 
-func (m *database) onDataChanged(changeDoc map[string]interface{}) {
-	if changeDoc == nil {
-		return
+	startFilter := bson.D{
+		primitive.E{Key: "poll.status", Value: PollStatusCreated},
+		primitive.E{Key: "poll.start_at", Value: bson.M{"$ne": nil}},
 	}
-	log.Printf("onDataChanged: %+v\n", changeDoc)
-	ns := changeDoc["ns"]
-	if ns == nil {
-		return
+	startOptions := options.FindOne().SetSort(bson.D{primitive.E{Key: "poll.start_at", Value: 1}})
+	var startPoll model.Poll
+	err := sa.db.polls.FindOne(startFilter, &startPoll, startOptions)
+	if err != nil && err != mongo.ErrNoDocuments {
+		fmt.Printf("error storage.Adapter.GetNextPollScheduleTime - %s", err)
+		return nil, fmt.Errorf("error storage.Adapter.GetNextPollScheduleTime - %s", err)
+	}
+	if err == nil && startPoll.StartAt != nil {
+		next = startPoll.StartAt
 	}
-	nsMap := ns.(map[string]interface{})
-	coll := nsMap["coll"]
 
-	record := changeDoc["fullDocument"]
-	var recordMap map[string]interface{}
-	if record != nil {
-		recordMap = record.(map[string]interface{})
+	endFilter := bson.D{
+		primitive.E{Key: "poll.status", Value: bson.M{"$in": []string{PollStatusCreated, PollStatusStarted, PollStatusPaused}}},
+		primitive.E{Key: "poll.end_at", Value: bson.M{"$ne": nil}},
+	}
+	endOptions := options.FindOne().SetSort(bson.D{primitive.E{Key: "poll.end_at", Value: 1}})
+	var endPoll model.Poll
+	err = sa.db.polls.FindOne(endFilter, &endPoll, endOptions)
+	if err != nil && err != mongo.ErrNoDocuments {
+		fmt.Printf("error storage.Adapter.GetNextPollScheduleTime - %s", err)
+		return nil, fmt.Errorf("error storage.Adapter.GetNextPollScheduleTime - %s", err)
+	}
+	if err == nil && endPoll.EndAt != nil && (next == nil || endPoll.EndAt.Before(*next)) {
+		next = endPoll.EndAt
 	}
 
-	if m.listener != nil {
-		m.listener.OnCollectionUpdated(coll.(string), recordMap)
+	recurrenceFilter := bson.D{
+		primitive.E{Key: "next_instance_at", Value: bson.M{"$ne": nil}},
+		primitive.E{Key: "poll.recurrence.status", Value: bson.M{"$nin": []string{model.PollSeriesStatusPaused, model.PollSeriesStatusEnded}}},
+	}
+	recurrenceOptions := options.FindOne().SetSort(bson.D{primitive.E{Key: "next_instance_at", Value: 1}})
+	var recurringPoll model.Poll
+	err = sa.db.polls.FindOne(recurrenceFilter, &recurringPoll, recurrenceOptions)
+	if err != nil && err != mongo.ErrNoDocuments {
+		fmt.Printf("error storage.Adapter.GetNextPollScheduleTime - %s", err)
+		return nil, fmt.Errorf("error storage.Adapter.GetNextPollScheduleTime - %s", err)
+	}
+	if err == nil && recurringPoll.NextInstanceAt != nil && (next == nil || recurringPoll.NextInstanceAt.Before(*next)) {
+		next = recurringPoll.NextInstanceAt
+	}
+
+	return next, nil
+}
+
+// GetRecurringPollsDue gets the recurring polls of the active series which next instance time has come
+func (sa *Adapter) GetRecurringPollsDue(now time.Time) ([]model.Poll, error) {
+	filter := bson.D{
+		primitive.E{Key: "next_instance_at", Value: bson.M{"$lte": now}},
+		primitive.E{Key: "poll.recurrence.status", Value: bson.M{"$nin": []string{model.PollSeriesStatusPaused, model.PollSeriesStatusEnded}}},
+	}
+
+	var results []model.Poll
+	err := sa.db.polls.Find(filter, &results, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetRecurringPollsDue - %s", err)
+		return nil, fmt.Errorf("error storage.Adapter.GetRecurringPollsDue - %s", err)
+	}
+
+	return results, nil
+}
+
+// updatePollRecurrence moves a recurring poll to its next instance time in the transaction, only if the next instance time
+// is still the one of the poll. The series is complete when the next time is nil. Returns false if the poll has already been changed.
+func (sa *Adapter) updatePollRecurrence(ctx TransactionContext, poll model.Poll, nextInstanceAt *time.Time, instancesCount int) (bool, error) {
+	filter := bson.D{
+		primitive.E{Key: "org_id", Value: poll.OrgID},
+		primitive.E{Key: "_id", Value: poll.ID},
+		primitive.E{Key: "next_instance_at", Value: poll.NextInstanceAt},
+	}
+	set := bson.D{
+		primitive.E{Key: "instances_count", Value: instancesCount},
+	}
+	update := bson.D{}
+	if nextInstanceAt != nil {
+		set = append(set, primitive.E{Key: "next_instance_at", Value: *nextInstanceAt})
+	} else {
+		update = append(update, primitive.E{Key: "$unset", Value: bson.D{
+			primitive.E{Key: "next_instance_at", Value: ""},
+		}})
+	}
+	update = append(update, primitive.E{Key: "$set", Value: set})
+
+	res, err := sa.db.polls.UpdateOneWithContext(ctx, filter, update, nil)
+	if err != nil {
+		return false, err
+	}
+
+	return res.MatchedCount > 0, nil
+}
+
+// GetPollSeries gets the polls of a recurring series the user can see, the recurring poll included, oldest first
+func (sa *Adapter) GetPollSeries(user *model.User, seriesID string, membership *groups.GroupMembership) ([]model.Poll, error) {
+	seriesFilter := []primitive.M{{"poll.series_id": seriesID}}
+	if objID, err := primitive.ObjectIDFromHex(seriesID); err == nil {
+		seriesFilter = append(seriesFilter, primitive.M{"_id": objID})
+	}
+
+	var innerFilter primitive.M
+	if membership != nil && len(membership.GroupIDsAsAdmin) > 0 {
+		innerFilter = primitive.M{"$or": []primitive.M{
+			{"poll.group_id": bson.M{"$in": membership.GroupIDsAsAdmin}},
+			{"poll.to_members.user_id": user.Claims.Subject},
+		}}
+	} else {
+		innerFilter = primitive.M{"poll.to_members.user_id": user.Claims.Subject}
+	}
+
+	filter := bson.D{
+		primitive.E{Key: "org_id", Value: user.Claims.OrgID},
+		primitive.E{Key: "$and", Value: []primitive.M{
+			{"$or": seriesFilter},
+			{"$or": []primitive.M{
+				{"poll.to_members": primitive.Null{}},
+				{"poll.to_members": primitive.M{"$exists": true, "$size": 0}},
+				{"poll.userid": user.Claims.Subject},
+				{"poll.co_owners": user.Claims.Subject},
+				innerFilter,
+			}},
+		}},
+	}
+	findOptions := options.Find().SetSort(bson.D{
+		primitive.E{Key: "poll.date_created", Value: 1},
+		primitive.E{Key: "_id", Value: 1},
+	})
+
+	var polls []model.Poll
+	err := sa.db.polls.Find(filter, &polls, findOptions)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetPollSeries(%s) - %s", seriesID, err)
+		return nil, fmt.Errorf("error storage.Adapter.GetPollSeries(%s) - %s", seriesID, err)
+	}
+
+	err = sa.setUserVotes(user, polls)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetPollSeries(%s) - %s", seriesID, err)
+		return nil, fmt.Errorf("error storage.Adapter.GetPollSeries(%s) - %s", seriesID, err)
+	}
+
+	return polls, nil
+}
+
+// UpdatePollStatus applies the status transition and records it, only if the poll status is still the transition from status.
+// A reopened poll reserves its PIN again. Returns false if the poll has not been updated because it has already been changed.
+func (sa *Adapter) UpdatePollStatus(poll model.Poll, transition model.PollStatusTransition) (bool, error) {
+	filter := bson.D{
+		primitive.E{Key: "org_id", Value: poll.OrgID},
+		primitive.E{Key: "_id", Value: poll.ID},
+		primitive.E{Key: "poll.status", Value: transition.FromStatus},
+	}
+	set := bson.D{
+		primitive.E{Key: "poll.status", Value: transition.ToStatus},
+		primitive.E{Key: "poll.date_updated", Value: transition.Date},
+	}
+	update := bson.D{
+		primitive.E{Key: "$push", Value: bson.D{
+			primitive.E{Key: "transitions", Value: transition},
+		}},
+	}
+	if transition.ToStatus == PollStatusTerminated {
+		// free the PIN
+		update = append(update, primitive.E{Key: "$unset", Value: bson.D{
+			primitive.E{Key: "active_pin", Value: ""},
+		}})
+	} else if transition.FromStatus == PollStatusTerminated && poll.Pin > 0 {
+		// the PIN may be used by another active poll in the meantime
+		filter = append(filter, primitive.E{Key: "poll.pin", Value: poll.Pin})
+		set = append(set, primitive.E{Key: "active_pin", Value: poll.Pin})
+	}
+	update = append(update, primitive.E{Key: "$set", Value: set})
+
+	res, err := sa.db.polls.UpdateOne(filter, update, nil)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, fmt.Errorf("%w: %d", model.ErrPollPinInUse, poll.Pin)
+	}
+	if err != nil {
+		fmt.Printf("error storage.Adapter.UpdatePollStatus(%s) - %s", poll.ID.Hex(), err)
+		return false, fmt.Errorf("error storage.Adapter.UpdatePollStatus(%s) - %s", poll.ID.Hex(), err)
+	}
+
+	return res.MatchedCount > 0, nil
+}
+
+// DeletePoll deletes a poll with its votes and quiz scores and removes it from the sessions in one transaction
+func (sa *Adapter) DeletePoll(user *model.User, id string) error {
+	if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+		err = sa.PerformTransaction(func(ctx TransactionContext) error {
+			filter := bson.D{
+				primitive.E{Key: "org_id", Value: user.Claims.OrgID},
+				primitive.E{Key: "_id", Value: objID},
+			}
+			_, err := sa.db.polls.DeleteOneWithContext(ctx, filter, nil)
+			if err != nil {
+				return fmt.Errorf("error while delete poll - %w", err)
+			}
+
+			_, err = sa.db.pollVotes.DeleteManyWithContext(ctx, bson.D{primitive.E{Key: "poll_id", Value: objID}}, nil)
+			if err != nil {
+				return fmt.Errorf("error while delete poll votes - %w", err)
+			}
+
+			_, err = sa.db.quizScores.DeleteManyWithContext(ctx, bson.D{primitive.E{Key: "poll_id", Value: id}}, nil)
+			if err != nil {
+				return fmt.Errorf("error while delete quiz scores - %w", err)
+			}
+
+			sessionsFilter := bson.M{"org_id": user.Claims.OrgID, "poll_ids": id}
+			_, err = sa.db.pollSessions.UpdateManyWithContext(ctx, sessionsFilter, bson.M{"$pull": bson.M{"poll_ids": id}}, nil)
+			if err != nil {
+				return fmt.Errorf("error while remove the poll from the sessions - %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Printf("error storage.Adapter.DeletePoll(%s) - %s", id, err)
+			return fmt.Errorf("error storage.Adapter.DeletePoll(%s) - %s", id, err)
+		}
+	}
+	return nil
+
+}
+
+// VotePoll votes a poll. The vote is stored in the poll votes collection only if the poll is started, the answer fits
+// its options and choice rules and the user has not voted yet (unless the poll allows repeated votes or vote changes).
+// The vote and the poll counters are stored in one transaction, the counters are updated with a conditional update
+// and nothing is stored if the poll does not accept the vote anymore.
+// Returns the poll with the updated counters.
+func (sa *Adapter) VotePoll(user *model.User, pollID string, vote model.PollVote) (*model.Poll, error) {
+	objID, err := primitive.ObjectIDFromHex(pollID)
+	if err != nil {
+		return nil, fmt.Errorf("error storage.Adapter.VotePoll(%s) - unable to construct obj id: %w", pollID, model.ErrPollNotFound)
+	}
+
+	pollFilter := bson.D{
+		primitive.E{Key: "_id", Value: objID},
+		primitive.E{Key: "org_id", Value: user.Claims.OrgID},
+	}
+	var poll model.Poll
+	err = sa.db.polls.FindOne(pollFilter, &poll, nil)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("error storage.Adapter.VotePoll(%s) - %w", pollID, model.ErrPollNotFound)
+	}
+	if err != nil {
+		fmt.Printf("error storage.Adapter.VotePoll(%s) - %s", pollID, err)
+		return nil, fmt.Errorf("error storage.Adapter.VotePoll(%s) - %s", pollID, err)
+	}
+	if poll.Status != PollStatusStarted {
+		return nil, fmt.Errorf("%w: status is %s", model.ErrPollNotStarted, poll.Status)
+	}
+	err = poll.ValidateVote(vote)
+	if err != nil {
+		return nil, err
+	}
+	err = poll.ValidateVoteLocation(vote.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	vote.Created = now
+
+	maxAnswer := 0
+	for _, a := range vote.Answer {
+		if a > maxAnswer {
+			maxAnswer = a
+		}
+	}
+	filter := append(pollFilter, primitive.E{Key: "poll.status", Value: PollStatusStarted})
+	if poll.IsScale() {
+		filter = append(filter, primitive.E{Key: "poll.poll_type", Value: poll.PollType})
+		filter = append(filter, primitive.E{Key: "poll.scale", Value: poll.Scale})
+	} else if poll.IsOpenText() {
+		filter = append(filter, primitive.E{Key: "poll.poll_type", Value: poll.PollType})
+	} else {
+		filter = append(filter, primitive.E{Key: fmt.Sprintf("poll.options.%d", maxAnswer), Value: bson.M{"$exists": true}})
+	}
+	if len(vote.Answer) > 1 && !poll.IsRanked() {
+		filter = append(filter, primitive.E{Key: "poll.multi_choice", Value: true})
+	}
+
+	var updatedPoll *model.Poll
+	err = sa.PerformTransaction(func(ctx TransactionContext) error {
+		//store the vote
+		newVoter, replaced, err := sa.storePollVote(ctx, user, poll, vote)
+		if err != nil {
+			return err
+		}
+
+		//update the counters, the replaced votes are not counted anymore
+		changes := map[string]int{}
+		addVoteCounterChanges(changes, poll, vote, 1)
+		for _, replacedVote := range replaced {
+			addVoteCounterChanges(changes, poll, replacedVote, -1)
+		}
+		if newVoter {
+			changes["counters.unique_voters"] = 1
+		}
+
+		countersFilter := filter
+		optionDelta := 0
+		if poll.AutoClose != nil {
+			//the poll does not accept votes over the auto close limits (see PollAutoClose.AcceptsVote), it is ended once they are reached
+			key := fmt.Sprintf("counters.options.%s", poll.AutoClose.OptionCounterKey())
+			optionDelta = changes[key]
+			if poll.AutoClose.MaxVoters > 0 && newVoter {
+				countersFilter = append(countersFilter, primitive.E{Key: "counters.unique_voters", Value: bson.M{"$not": bson.M{"$gte": poll.AutoClose.MaxVoters}}})
+			}
+			if poll.AutoClose.OptionVotes > 0 && optionDelta > 0 {
+				countersFilter = append(countersFilter, primitive.E{Key: key, Value: bson.M{"$not": bson.M{"$gte": poll.AutoClose.OptionVotes}}})
+			}
+		}
+
+		updatedPoll, err = sa.updatePollCounters(ctx, countersFilter, changes, now)
+		if err != nil {
+			return err
+		}
+		if updatedPoll == nil {
+			return sa.pollVoteRejection(ctx, pollFilter, newVoter, optionDelta)
+		}
+		return nil
+	})
+	if err != nil {
+		if err == model.ErrAlreadyVoted || err == model.ErrPollVoteLimitReached {
+			return nil, err
+		}
+		fmt.Printf("error storage.Adapter.VotePoll(%s) - %s", pollID, err)
+		return nil, fmt.Errorf("error storage.Adapter.VotePoll(%s) - %w", pollID, err)
+	}
+
+	return updatedPoll, nil
+}
+
+// RetractPollVote removes the votes of the user for a started poll which allows vote changes
+func (sa *Adapter) RetractPollVote(user *model.User, pollID string) error {
+	objID, err := primitive.ObjectIDFromHex(pollID)
+	if err != nil {
+		return fmt.Errorf("error storage.Adapter.RetractPollVote(%s) - unable to construct obj id: %w", pollID, model.ErrPollNotFound)
+	}
+
+	pollFilter := bson.D{
+		primitive.E{Key: "_id", Value: objID},
+		primitive.E{Key: "org_id", Value: user.Claims.OrgID},
+	}
+	var poll model.Poll
+	err = sa.db.polls.FindOne(pollFilter, &poll, nil)
+	if err == mongo.ErrNoDocuments {
+		return fmt.Errorf("error storage.Adapter.RetractPollVote(%s) - %w", pollID, model.ErrPollNotFound)
+	}
+	if err != nil {
+		fmt.Printf("error storage.Adapter.RetractPollVote(%s) - %s", pollID, err)
+		return fmt.Errorf("error storage.Adapter.RetractPollVote(%s) - %s", pollID, err)
+	}
+	if poll.Status != PollStatusStarted {
+		return fmt.Errorf("%w: status is %s", model.ErrPollNotStarted, poll.Status)
+	}
+	if !poll.AllowVoteChange {
+		return model.ErrVoteChangeNotAllowed
+	}
+
+	votesFilter := bson.D{
+		primitive.E{Key: "org_id", Value: user.Claims.OrgID},
+		primitive.E{Key: "poll_id", Value: objID},
+		primitive.E{Key: "user_id", Value: user.Claims.Subject},
+	}
+	filter := append(pollFilter, primitive.E{Key: "poll.status", Value: PollStatusStarted})
+
+	err = sa.PerformTransaction(func(ctx TransactionContext) error {
+		//remove the votes
+		var userVotes model.PollUserVotes
+		err := sa.db.pollVotes.FindOneAndDeleteWithContext(ctx, votesFilter, &userVotes, nil)
+		if err == mongo.ErrNoDocuments {
+			return model.ErrVoteNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		//update the counters
+		changes := map[string]int{"counters.unique_voters": -1}
+		for _, vote := range userVotes.Votes {
+			addVoteCounterChanges(changes, poll, vote, -1)
+		}
+		updatedPoll, err := sa.updatePollCounters(ctx, filter, changes, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		if updatedPoll == nil {
+			return fmt.Errorf("%w: the poll has been changed", model.ErrPollNotStarted)
+		}
+		return nil
+	})
+	if err != nil {
+		if err == model.ErrVoteNotFound {
+			return err
+		}
+		fmt.Printf("error storage.Adapter.RetractPollVote(%s) - %s", pollID, err)
+		return fmt.Errorf("error storage.Adapter.RetractPollVote(%s) - %w", pollID, err)
+	}
+
+	return nil
+}
+
+// addVoteCounterChanges adds the counter changes of a vote. A ranking counts for its most preferred option only,
+// a scale value counts for its step and a text answer counts for the unique voters only.
+func addVoteCounterChanges(changes map[string]int, poll model.Poll, vote model.PollVote, delta int) {
+	if poll.IsScale() {
+		if vote.Value != nil {
+			stepIndex, _ := poll.Scale.StepIndex(*vote.Value)
+			changes[fmt.Sprintf("counters.options.%d", stepIndex)] += delta
+		}
+		return
+	}
+
+	for i, a := range vote.Answer {
+		if i == 0 || !poll.IsRanked() {
+			changes[fmt.Sprintf("counters.options.%d", a)] += delta
+		}
+	}
+}
+
+// updatePollCounters applies the counter changes to the poll matching the filter and increments the counters revision.
+// Returns the updated poll, or nil if the poll does not match the filter anymore.
+func (sa *Adapter) updatePollCounters(ctx TransactionContext, filter bson.D, changes map[string]int, now time.Time) (*model.Poll, error) {
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	inc := bson.D{}
+	for _, key := range keys {
+		if changes[key] != 0 {
+			inc = append(inc, primitive.E{Key: key, Value: changes[key]})
+		}
+	}
+	inc = append(inc, primitive.E{Key: "counters.revision", Value: 1})
+
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "poll.date_updated", Value: now},
+		}},
+		primitive.E{Key: "$inc", Value: inc},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updatedPoll model.Poll
+	err := sa.db.polls.FindOneAndUpdateWithContext(ctx, filter, update, &updatedPoll, opts)
+	if err == mongo.ErrNoDocuments {
+		// the poll has been changed in the meantime
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &updatedPoll, nil
+}
+
+// UpdatePollTally recalculates the instant-runoff tally of a ranked poll or the results of an open text poll
+// if they are older than the poll counters. The votes are read after the poll, so they include all changes up to
+// its counters revision. A tally is never replaced by one for an older revision.
+func (sa *Adapter) UpdatePollTally(orgID string, pollID string) error {
+	objID, err := primitive.ObjectIDFromHex(pollID)
+	if err != nil {
+		return fmt.Errorf("error storage.Adapter.UpdatePollTally(%s) - unable to construct obj id", pollID)
+	}
+
+	var poll model.Poll
+	err = sa.db.polls.FindOne(bson.D{primitive.E{Key: "_id", Value: objID}, primitive.E{Key: "org_id", Value: orgID}}, &poll, nil)
+	if err == mongo.ErrNoDocuments {
+		// deleted in the meantime
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error storage.Adapter.UpdatePollTally(%s) - %s", pollID, err)
+	}
+
+	revision := 0
+	if poll.Counters != nil {
+		revision = poll.Counters.Revision
+	}
+	if poll.IsRanked() && poll.Runoff != nil && poll.Runoff.Revision >= revision {
+		return nil
+	}
+	if poll.IsOpenText() && poll.TextResults != nil && poll.TextResults.Revision >= revision {
+		return nil
+	}
+	if !poll.IsRanked() && !poll.IsOpenText() {
+		return nil
+	}
+
+	userVotes, err := sa.GetPollVotes(orgID, pollID)
+	if err != nil {
+		return err
+	}
+
+	var field string
+	var tally interface{}
+	if poll.IsRanked() {
+		ballots := [][]int{}
+		for _, item := range userVotes {
+			for _, vote := range item.Votes {
+				ballots = append(ballots, vote.Answer)
+			}
+		}
+		runoff := model.CalculateInstantRunoff(len(poll.Options), ballots)
+		runoff.Revision = revision
+		field, tally = "runoff", runoff
+	} else {
+		textResults := model.CalculateTextResults(toPollTextAnswers(userVotes, false))
+		textResults.Revision = revision
+		field, tally = "text_results", textResults
+	}
+
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: poll.ID},
+		primitive.E{Key: "$or", Value: []bson.M{
+			{field: bson.M{"$exists": false}},
+			{field + ".revision": bson.M{"$lt": revision}},
+		}},
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: field, Value: tally},
+		}},
+	}
+	_, err = sa.db.polls.UpdateOne(filter, update, nil)
+	if err != nil {
+		return fmt.Errorf("error storage.Adapter.UpdatePollTally(%s) - %s", pollID, err)
+	}
+	return nil
+}
+
+// GetPollsWithStaleTally gets the ranked and open text polls whose tally is older than their counters
+func (sa *Adapter) GetPollsWithStaleTally() ([]model.Poll, error) {
+	staleExpr := func(field string) bson.M {
+		return bson.M{"$lt": bson.A{bson.M{"$ifNull": bson.A{"$" + field + ".revision", -1}}, "$counters.revision"}}
+	}
+	filter := bson.D{
+		primitive.E{Key: "$or", Value: []bson.M{
+			{"poll.poll_type": model.PollTypeRanked, "$expr": staleExpr("runoff")},
+			{"poll.poll_type": model.PollTypeOpenText, "$expr": staleExpr("text_results")},
+		}},
+	}
+
+	var results []model.Poll
+	err := sa.db.polls.Find(filter, &results, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetPollsWithStaleTally - %s", err)
+		return nil, fmt.Errorf("error storage.Adapter.GetPollsWithStaleTally - %s", err)
+	}
+	return results, nil
+}
+
+// GetPollTextAnswers gets all text answers of an open text poll, including the pending and hidden ones
+func (sa *Adapter) GetPollTextAnswers(orgID string, pollID string, withUserIDs bool) ([]model.PollTextAnswer, error) {
+	userVotes, err := sa.GetPollVotes(orgID, pollID)
+	if err != nil {
+		return nil, err
+	}
+	return toPollTextAnswers(userVotes, withUserIDs), nil
+}
+
+// ModeratePollTextAnswer applies a moderation decision to a text answer and increments the poll counters revision, so the text results are recalculated
+func (sa *Adapter) ModeratePollTextAnswer(orgID string, pollID string, answerID string, moderation model.PollTextModeration) error {
+	objID, err := primitive.ObjectIDFromHex(pollID)
+	if err != nil {
+		return fmt.Errorf("error storage.Adapter.ModeratePollTextAnswer(%s) - unable to construct obj id", pollID)
+	}
+
+	set := bson.D{}
+	if moderation.Status != nil {
+		set = append(set, primitive.E{Key: "votes.$.status", Value: *moderation.Status})
+	}
+	if moderation.Pinned != nil {
+		set = append(set, primitive.E{Key: "votes.$.pinned", Value: *moderation.Pinned})
+	}
+	if len(set) == 0 {
+		return nil
+	}
+
+	filter := bson.D{
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "poll_id", Value: objID},
+		primitive.E{Key: "votes.id", Value: answerID},
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: set},
+	}
+	res, err := sa.db.pollVotes.UpdateOne(filter, update, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.ModeratePollTextAnswer(%s) - %s", pollID, err)
+		return fmt.Errorf("error storage.Adapter.ModeratePollTextAnswer(%s) - %s", pollID, err)
+	}
+	if res.MatchedCount == 0 {
+		return model.ErrTextAnswerNotFound
+	}
+
+	//the results change, increment the revision to recalculate them
+	pollFilter := bson.D{
+		primitive.E{Key: "_id", Value: objID},
+		primitive.E{Key: "org_id", Value: orgID},
+	}
+	pollUpdate := bson.D{
+		primitive.E{Key: "$inc", Value: bson.D{
+			primitive.E{Key: "counters.revision", Value: 1},
+		}},
+	}
+	_, err = sa.db.polls.UpdateOne(pollFilter, pollUpdate, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.ModeratePollTextAnswer(%s) - %s", pollID, err)
+		return fmt.Errorf("error storage.Adapter.ModeratePollTextAnswer(%s) - %s", pollID, err)
+	}
+	return nil
+}
+
+// toPollTextAnswers extracts the text answers from the votes
+func toPollTextAnswers(userVotes []model.PollUserVotes, withUserIDs bool) []model.PollTextAnswer {
+	answers := []model.PollTextAnswer{}
+	for _, item := range userVotes {
+		for _, vote := range item.Votes {
+			if len(vote.ID) == 0 {
+				continue
+			}
+
+			answer := model.PollTextAnswer{ID: vote.ID, Text: vote.Text, Status: vote.Status, Pinned: vote.Pinned, Created: vote.Created}
+			if withUserIDs {
+				answer.UserID = item.UserID
+			}
+			answers = append(answers, answer)
+		}
+	}
+	return answers
+}
+
+// pollVoteRejection gets the error for a vote which does not match the poll anymore. The poll is ended or changed,
+// or the vote would go over an auto close limit which has been reached in the meantime.
+func (sa *Adapter) pollVoteRejection(ctx TransactionContext, pollFilter bson.D, newVoter bool, optionDelta int) error {
+	var poll model.Poll
+	err := sa.db.polls.FindOneWithContext(ctx, pollFilter, &poll, nil)
+	if err != nil {
+		return err
+	}
+	if poll.Status == PollStatusStarted && poll.AutoClose != nil && poll.Counters != nil &&
+		!poll.AutoClose.AcceptsVote(*poll.Counters, newVoter, optionDelta) {
+		return model.ErrPollVoteLimitReached
+	}
+	return fmt.Errorf("%w: the poll has been changed", model.ErrPollNotStarted)
+}
+
+// storePollVote stores the vote within the transaction. A repeated vote is added to the user votes and a changed vote replaces them.
+// Returns true if it is the first vote of the user, and the replaced votes.
+func (sa *Adapter) storePollVote(ctx TransactionContext, user *model.User, poll model.Poll, vote model.PollVote) (bool, []model.PollVote, error) {
+	pollID := poll.ID
+	filter := bson.D{
+		primitive.E{Key: "poll_id", Value: pollID},
+		primitive.E{Key: "user_id", Value: vote.UserID},
+	}
+
+	if poll.Repeat {
+		update := bson.D{
+			primitive.E{Key: "$setOnInsert", Value: bson.D{
+				primitive.E{Key: "_id", Value: primitive.NewObjectID()},
+				primitive.E{Key: "org_id", Value: user.Claims.OrgID},
+				primitive.E{Key: "date_created", Value: vote.Created},
+			}},
+			primitive.E{Key: "$set", Value: bson.D{
+				primitive.E{Key: "date_updated", Value: vote.Created},
+			}},
+			primitive.E{Key: "$push", Value: bson.D{
+				primitive.E{Key: "votes", Value: vote},
+			}},
+		}
+		opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+
+		var previous model.PollUserVotes
+		err := sa.db.pollVotes.FindOneAndUpdateWithContext(ctx, filter, update, &previous, opts)
+		if err == mongo.ErrNoDocuments {
+			return true, nil, nil
+		}
+		if err != nil {
+			return false, nil, fmt.Errorf("error storage.Adapter.storePollVote(%s) - %w", pollID.Hex(), err)
+		}
+		return false, nil, nil
+	}
+
+	//a failed write aborts the transaction, so the user votes are looked up before they are inserted
+	var previous model.PollUserVotes
+	err := sa.db.pollVotes.FindOneWithContext(ctx, filter, &previous, nil)
+	if err == mongo.ErrNoDocuments {
+		userVotes := model.PollUserVotes{
+			ID:          primitive.NewObjectID(),
+			OrgID:       user.Claims.OrgID,
+			PollID:      pollID,
+			UserID:      vote.UserID,
+			Votes:       []model.PollVote{vote},
+			DateCreated: vote.Created,
+			DateUpdated: vote.Created,
+		}
+		_, err = sa.db.pollVotes.InsertOneWithContext(ctx, userVotes)
+		if mongo.IsDuplicateKeyError(err) {
+			// the user has voted in the meantime
+			return false, nil, model.ErrAlreadyVoted
+		}
+		if err != nil {
+			return false, nil, fmt.Errorf("error storage.Adapter.storePollVote(%s) - %w", pollID.Hex(), err)
+		}
+		return true, nil, nil
+	}
+	if err != nil {
+		return false, nil, fmt.Errorf("error storage.Adapter.storePollVote(%s) - %w", pollID.Hex(), err)
+	}
+	if !poll.AllowVoteChange {
+		return false, nil, model.ErrAlreadyVoted
+	}
+
+	//replace the vote
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "votes", Value: []model.PollVote{vote}},
+			primitive.E{Key: "date_updated", Value: vote.Created},
+		}},
+	}
+	_, err = sa.db.pollVotes.UpdateOneWithContext(ctx, filter, update, nil)
+	if err != nil {
+		return false, nil, fmt.Errorf("error storage.Adapter.storePollVote(%s) - %w", pollID.Hex(), err)
+	}
+	return false, previous.Votes, nil
+}
+
+// GetPollVotes gets the votes of all users for a poll
+func (sa *Adapter) GetPollVotes(orgID string, pollID string) ([]model.PollUserVotes, error) {
+	objID, err := primitive.ObjectIDFromHex(pollID)
+	if err != nil {
+		return nil, fmt.Errorf("error storage.Adapter.GetPollVotes(%s) - unable to construct obj id", pollID)
+	}
+
+	filter := bson.D{
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "poll_id", Value: objID},
+	}
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "date_created", Value: 1}})
+
+	var results []model.PollUserVotes
+	err = sa.db.pollVotes.Find(filter, &results, findOptions)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetPollVotes(%s) - %s", pollID, err)
+		return nil, fmt.Errorf("error storage.Adapter.GetPollVotes(%s) - %s", pollID, err)
+	}
+
+	return results, nil
+}
+
+// ExportPollVotes passes the votes of all users for a poll to the handler one by one, the oldest voters first
+func (sa *Adapter) ExportPollVotes(orgID string, pollID string, handler func(userVotes model.PollUserVotes) error) error {
+	objID, err := primitive.ObjectIDFromHex(pollID)
+	if err != nil {
+		return fmt.Errorf("error storage.Adapter.ExportPollVotes(%s) - unable to construct obj id", pollID)
+	}
+
+	filter := bson.D{
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "poll_id", Value: objID},
+	}
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "date_created", Value: 1}})
+
+	err = sa.db.pollVotes.FindEach(filter, findOptions, func(cur *mongo.Cursor) error {
+		var userVotes model.PollUserVotes
+		err := cur.Decode(&userVotes)
+		if err != nil {
+			return err
+		}
+		return handler(userVotes)
+	})
+	if err != nil {
+		fmt.Printf("error storage.Adapter.ExportPollVotes(%s) - %s", pollID, err)
+		return fmt.Errorf("error storage.Adapter.ExportPollVotes(%s) - %s", pollID, err)
+	}
+	return nil
+}
+
+// GetUserPollVotes gets the votes of the current user for the polls
+func (sa *Adapter) GetUserPollVotes(user *model.User, pollIDs []primitive.ObjectID) ([]model.PollUserVotes, error) {
+	filter := bson.D{
+		primitive.E{Key: "org_id", Value: user.Claims.OrgID},
+		primitive.E{Key: "user_id", Value: user.Claims.Subject},
+	}
+	if pollIDs != nil {
+		filter = append(filter, primitive.E{Key: "poll_id", Value: bson.M{"$in": pollIDs}})
+	}
+
+	var results []model.PollUserVotes
+	err := sa.db.pollVotes.Find(filter, &results, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetUserPollVotes - %s", err)
+		return nil, fmt.Errorf("error storage.Adapter.GetUserPollVotes - %s", err)
+	}
+
+	return results, nil
+}
+
+// GetPollVoterIDs gets which of the users have voted for a poll
+func (sa *Adapter) GetPollVoterIDs(orgID string, pollID string, userIDs []string) ([]string, error) {
+	objID, err := primitive.ObjectIDFromHex(pollID)
+	if err != nil {
+		return nil, fmt.Errorf("error storage.Adapter.GetPollVoterIDs(%s) - unable to construct obj id", pollID)
+	}
+
+	filter := bson.D{
+		primitive.E{Key: "org_id", Value: orgID},
+		primitive.E{Key: "poll_id", Value: objID},
+		primitive.E{Key: "user_id", Value: bson.M{"$in": userIDs}},
+	}
+
+	values, err := sa.db.pollVotes.Distinct("user_id", filter)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetPollVoterIDs(%s) - %s", pollID, err)
+		return nil, fmt.Errorf("error storage.Adapter.GetPollVoterIDs(%s) - %s", pollID, err)
+	}
+
+	ids := []string{}
+	for _, value := range values {
+		if id, ok := value.(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// getVotedPollIDs gets the ids of the polls the user has voted
+func (sa *Adapter) getVotedPollIDs(user *model.User) ([]primitive.ObjectID, error) {
+	filter := bson.D{
+		primitive.E{Key: "org_id", Value: user.Claims.OrgID},
+		primitive.E{Key: "user_id", Value: user.Claims.Subject},
+	}
+
+	values, err := sa.db.pollVotes.Distinct("poll_id", filter)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.getVotedPollIDs - %s", err)
+		return nil, fmt.Errorf("error storage.Adapter.getVotedPollIDs - %s", err)
+	}
+
+	ids := []primitive.ObjectID{}
+	for _, value := range values {
+		if id, ok := value.(primitive.ObjectID); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// setUserVotes sets the current user votes as poll responses
+func (sa *Adapter) setUserVotes(user *model.User, polls []model.Poll) error {
+	if len(polls) == 0 {
+		return nil
+	}
+
+	pollIDs := make([]primitive.ObjectID, len(polls))
+	for i, poll := range polls {
+		pollIDs[i] = poll.ID
+	}
+
+	userVotes, err := sa.GetUserPollVotes(user, pollIDs)
+	if err != nil {
+		return err
+	}
+
+	votesMapping := map[primitive.ObjectID][]model.PollVote{}
+	for _, item := range userVotes {
+		votesMapping[item.PollID] = item.Votes
+	}
+	for i := range polls {
+		polls[i].Responses = votesMapping[polls[i].ID]
+	}
+	return nil
+}
+
+// SetListener sets the upper layer listener for sending collection changed callbacks
+func (sa *Adapter) SetListener(listener CollectionListener) {
+	sa.db.listener = listener
+}
+
+// Event
+
+func (m *database) onDataChanged(changeDoc map[string]interface{}) {
+	if changeDoc == nil {
+		return
+	}
+	log.Printf("onDataChanged: %+v\n", changeDoc)
+	ns := changeDoc["ns"]
+	if ns == nil {
+		return
+	}
+	nsMap := ns.(map[string]interface{})
+	coll := nsMap["coll"]
+
+	record := changeDoc["fullDocument"]
+	var recordMap map[string]interface{}
+	if record != nil {
+		recordMap = record.(map[string]interface{})
+	}
+
+	if m.listener != nil {
+		m.listener.OnCollectionUpdated(coll.(string), recordMap)
+	}
+}
+
+// GetSurvey retrieves a single survey
+func (sa *Adapter) GetSurvey(user *model.User, id string) (*model.Survey, error) {
+	filter := bson.M{"_id": id, "org_id": user.Claims.OrgID, "app_id": user.Claims.AppID}
+	var entry model.Survey
+	err := sa.db.surveys.FindOne(filter, &entry, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetSurvey(%s) - %s", id, err)
+		return nil, fmt.Errorf("error storage.Adapter.GetSurvey(%s) - %s", id, err)
+	}
+
+	return &entry, nil
+}
+
+// GetSurveys retrieves the surveys of the organization and the app by filter
+func (sa *Adapter) GetSurveys(user *model.User, filter model.SurveysFilter) ([]model.Survey, error) {
+	mongoFilter := bson.M{"org_id": user.Claims.OrgID, "app_id": user.Claims.AppID}
+	if len(filter.Types) > 0 {
+		mongoFilter["type"] = bson.M{"$in": filter.Types}
+	}
+
+	findOptions := options.Find()
+	search := strings.TrimSpace(filter.Search)
+	if len(search) > 0 {
+		mongoFilter["$text"] = bson.M{"$search": search}
+		findOptions.SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}})
+		findOptions.SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "date_created", Value: -1}})
+	} else {
+		findOptions.SetSort(bson.D{{Key: "date_created", Value: -1}})
+	}
+	if filter.Limit != nil {
+		findOptions.SetLimit(*filter.Limit)
+	}
+	if filter.Offset != nil {
+		findOptions.SetSkip(*filter.Offset)
 	}
-}
 
-// GetSurvey retrieves a single survey
-func (sa *Adapter) GetSurvey(user *model.User, id string) (*model.Survey, error) {
-	filter := bson.M{"_id": id, "org_id": user.Claims.OrgID, "app_id": user.Claims.AppID}
-	var entry model.Survey
-	err := sa.db.surveys.FindOne(filter, &entry, nil)
+	results := []model.Survey{}
+	err := sa.db.surveys.Find(mongoFilter, &results, findOptions)
 	if err != nil {
-		fmt.Printf("error storage.Adapter.GetSurvey(%s) - %s", id, err)
-		return nil, fmt.Errorf("error storage.Adapter.GetSurvey(%s) - %s", id, err)
+		fmt.Printf("error storage.Adapter.GetSurveys - %s", err)
+		return nil, fmt.Errorf("error storage.Adapter.GetSurveys - %s", err)
 	}
 
-	return &entry, nil
+	return results, nil
 }
 
 // CreateSurvey creates a poll
 func (sa *Adapter) CreateSurvey(survey model.Survey) (*model.Survey, error) {
+	survey.SearchTexts = survey.GetSearchTexts()
 	_, err := sa.db.surveys.InsertOne(survey)
 	if err != nil {
 		fmt.Printf("error storage.Adapter.CreateSurvey(%s) - %s", survey.ID, err)
@@ -474,6 +1763,7 @@ func (sa *Adapter) UpdateSurvey(user *model.User, survey model.Survey, admin boo
 			"title":                 survey.Title,
 			"more_info":             survey.MoreInfo,
 			"data":                  survey.Data,
+			"search_texts":          survey.GetSearchTexts(),
 			"scored":                survey.Scored,
 			"result_rules":          survey.ResultRules,
 			"type":                  survey.Type,
@@ -555,8 +1845,8 @@ func (sa *Adapter) GetSurveysByUserID(user *model.User) ([]model.Survey, error)
 	return entry, nil
 }
 
-// GetSurveyResponses gets matching surveys for a user
-func (sa *Adapter) GetSurveyResponses(user *model.User, surveyIDs []string, surveyTypes []string, startDate *time.Time, endDate *time.Time, limit *int, offset *int) ([]model.SurveyResponse, error) {
+// GetSurveyResponses gets matching surveys for a user. The offset is ignored when the cursor of the previous page is set.
+func (sa *Adapter) GetSurveyResponses(user *model.User, surveyIDs []string, surveyTypes []string, startDate *time.Time, endDate *time.Time, limit *int, offset *int, cursor *string) ([]model.SurveyResponse, error) {
 	filter := bson.M{"user_id": user.Claims.Subject, "org_id": user.Claims.OrgID, "app_id": user.Claims.AppID}
 	if len(surveyIDs) > 0 {
 		filter["survey._id"] = bson.M{"$in": surveyIDs}
@@ -575,11 +1865,31 @@ func (sa *Adapter) GetSurveyResponses(user *model.User, surveyIDs []string, surv
 		filter["date_created"] = dateFilter
 	}
 
-	opts := options.Find().SetSort(bson.M{"date_created": -1})
+	if cursor != nil {
+		pageCursor, err := model.DecodePageCursor(*cursor)
+		if err != nil {
+			return nil, err
+		}
+		if pageCursor != nil {
+			if pageCursor.Date == nil {
+				return nil, fmt.Errorf("%w: missing date", model.ErrInvalidCursor)
+			}
+
+			//the responses after the cursor in the date and id order
+			filter["$and"] = []bson.M{
+				{"$or": []bson.M{
+					{"date_created": bson.M{"$lt": *pageCursor.Date}},
+					{"date_created": *pageCursor.Date, "_id": bson.M{"$lt": pageCursor.ID}},
+				}},
+			}
+		}
+	}
+
+	opts := options.Find().SetSort(bson.D{primitive.E{Key: "date_created", Value: -1}, primitive.E{Key: "_id", Value: -1}})
 	if limit != nil {
 		opts.SetLimit(int64(*limit))
 	}
-	if offset != nil {
+	if offset != nil && cursor == nil {
 		opts.SetSkip(int64(*offset))
 	}
 	var results []model.SurveyResponse
@@ -796,49 +2106,551 @@ func (sa *Adapter) DeleteAlertContact(user *model.User, id string) error {
 	return nil
 }
 
-// GetAllPolls gets all polls
-func (sa *Adapter) GetAllPolls() ([]model.Poll, error) {
-	filter := bson.M{}
-	var results []model.Poll
-	err := sa.db.polls.Find(filter, &results, nil)
+// GetStadiums retrieves all stadiums of the organization
+func (sa *Adapter) GetStadiums(orgID string) ([]model.Stadium, error) {
+	filter := bson.M{"org_id": orgID}
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "name", Value: 1}})
+
+	results := []model.Stadium{}
+	err := sa.db.settings.Find(filter, &results, findOptions)
 	if err != nil {
-		fmt.Printf("error storage.Adapter.GetAllPolls - %s", err)
-		return nil, fmt.Errorf("error storage.Adapter.GetAllPolls - %s", err)
+		fmt.Printf("error storage.Adapter.GetStadiums - %s", err)
+		return nil, fmt.Errorf("error storage.Adapter.GetStadiums - %s", err)
 	}
 
 	return results, nil
 }
 
-// PerformTransaction performs a transaction
-func (sa *Adapter) PerformTransaction(transaction func(context TransactionContext) error) error {
-	// transaction
-	err := sa.db.dbClient.UseSession(context.Background(), func(sessionContext mongo.SessionContext) error {
-		err := sessionContext.StartTransaction()
+// GetStadium retrieves a single stadium
+func (sa *Adapter) GetStadium(orgID string, id string) (*model.Stadium, error) {
+	filter := bson.M{"_id": id, "org_id": orgID}
+	var entry model.Stadium
+	err := sa.db.settings.FindOne(filter, &entry, nil)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("error storage.Adapter.GetStadium(%s) - %w", id, model.ErrStadiumNotFound)
+	}
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetStadium(%s) - %s", id, err)
+		return nil, fmt.Errorf("error storage.Adapter.GetStadium(%s) - %s", id, err)
+	}
+
+	return &entry, nil
+}
+
+// GetStadiumByKey retrieves the stadium with the key used by the polls. Returns nil if there is no such stadium.
+func (sa *Adapter) GetStadiumByKey(orgID string, key string) (*model.Stadium, error) {
+	filter := bson.M{settingsKey: key, "org_id": orgID}
+	var entry model.Stadium
+	err := sa.db.settings.FindOne(filter, &entry, nil)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetStadiumByKey(%s) - %s", key, err)
+		return nil, fmt.Errorf("error storage.Adapter.GetStadiumByKey(%s) - %s", key, err)
+	}
+
+	return &entry, nil
+}
+
+// CreateStadium creates a stadium
+func (sa *Adapter) CreateStadium(stadium model.Stadium) (*model.Stadium, error) {
+	err := sa.checkStadiumKey(stadium.OrgID, stadium.ID, stadium.Stadium)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = sa.db.settings.InsertOne(stadium)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.CreateStadium(%s) - %s", stadium.ID, err)
+		return nil, fmt.Errorf("error storage.Adapter.CreateStadium(%s) - %s", stadium.ID, err)
+	}
+
+	return &stadium, nil
+}
+
+// UpdateStadium updates a stadium
+func (sa *Adapter) UpdateStadium(orgID string, id string, stadium model.Stadium) error {
+	err := sa.checkStadiumKey(orgID, id, stadium.Stadium)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	filter := bson.M{"_id": id, "org_id": orgID}
+	update := bson.M{"$set": bson.M{
+		"stadium":       stadium.Stadium,
+		"name":          stadium.Name,
+		"geo_region":    stadium.GeoRegion,
+		"poll_defaults": stadium.PollDefaults,
+		"date_updated":  now,
+	}}
+
+	res, err := sa.db.settings.UpdateOne(filter, update, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.UpdateStadium(%s) - %s", id, err)
+		return fmt.Errorf("error storage.Adapter.UpdateStadium(%s) - %s", id, err)
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("error storage.Adapter.UpdateStadium(%s) - %w", id, model.ErrStadiumNotFound)
+	}
+
+	return nil
+}
+
+// DeleteStadium deletes a stadium. The polls keep the settings applied when they were created.
+func (sa *Adapter) DeleteStadium(orgID string, id string) error {
+	filter := bson.M{"_id": id, "org_id": orgID}
+	res, err := sa.db.settings.DeleteOne(filter, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.DeleteStadium(%s) - %s", id, err)
+		return fmt.Errorf("error storage.Adapter.DeleteStadium(%s) - %s", id, err)
+	}
+	if res.DeletedCount == 0 {
+		return fmt.Errorf("error storage.Adapter.DeleteStadium(%s) - %w", id, model.ErrStadiumNotFound)
+	}
+	return nil
+}
+
+// checkStadiumKey checks if the key is not used by another stadium of the organization
+func (sa *Adapter) checkStadiumKey(orgID string, id string, key string) error {
+	filter := bson.M{settingsKey: key, "org_id": orgID, "_id": bson.M{"$ne": id}}
+	count, err := sa.db.settings.CountDocuments(filter)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.checkStadiumKey(%s) - %s", key, err)
+		return fmt.Errorf("error storage.Adapter.checkStadiumKey(%s) - %s", key, err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %s", model.ErrStadiumExists, key)
+	}
+	return nil
+}
+
+// GetPollTemplates retrieves the personal templates of the user and the templates of the groups, sorted by name
+func (sa *Adapter) GetPollTemplates(orgID string, userID string, groupIDs []string) ([]model.PollTemplate, error) {
+	or := []bson.M{{"user_id": userID, "group_id": bson.M{"$exists": false}}}
+	if len(groupIDs) > 0 {
+		or = append(or, bson.M{"group_id": bson.M{"$in": groupIDs}})
+	}
+	filter := bson.M{"org_id": orgID, "$or": or}
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "name", Value: 1}})
+
+	results := []model.PollTemplate{}
+	err := sa.db.pollTemplates.Find(filter, &results, findOptions)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetPollTemplates - %s", err)
+		return nil, fmt.Errorf("error storage.Adapter.GetPollTemplates - %s", err)
+	}
+
+	return results, nil
+}
+
+// GetPollTemplate retrieves a single poll template
+func (sa *Adapter) GetPollTemplate(orgID string, id string) (*model.PollTemplate, error) {
+	filter := bson.M{"_id": id, "org_id": orgID}
+	var entry model.PollTemplate
+	err := sa.db.pollTemplates.FindOne(filter, &entry, nil)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("error storage.Adapter.GetPollTemplate(%s) - %w", id, model.ErrPollTemplateNotFound)
+	}
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetPollTemplate(%s) - %s", id, err)
+		return nil, fmt.Errorf("error storage.Adapter.GetPollTemplate(%s) - %s", id, err)
+	}
+
+	return &entry, nil
+}
+
+// CreatePollTemplate creates a poll template
+func (sa *Adapter) CreatePollTemplate(template model.PollTemplate) (*model.PollTemplate, error) {
+	_, err := sa.db.pollTemplates.InsertOne(template)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.CreatePollTemplate(%s) - %s", template.ID, err)
+		return nil, fmt.Errorf("error storage.Adapter.CreatePollTemplate(%s) - %s", template.ID, err)
+	}
+
+	return &template, nil
+}
+
+// UpdatePollTemplate updates a poll template
+func (sa *Adapter) UpdatePollTemplate(orgID string, id string, template model.PollTemplate) error {
+	now := time.Now().UTC()
+	filter := bson.M{"_id": id, "org_id": orgID}
+	update := bson.M{"$set": bson.M{
+		"name":         template.Name,
+		"poll":         template.Poll,
+		"date_updated": now,
+	}}
+
+	res, err := sa.db.pollTemplates.UpdateOne(filter, update, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.UpdatePollTemplate(%s) - %s", id, err)
+		return fmt.Errorf("error storage.Adapter.UpdatePollTemplate(%s) - %s", id, err)
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("error storage.Adapter.UpdatePollTemplate(%s) - %w", id, model.ErrPollTemplateNotFound)
+	}
+
+	return nil
+}
+
+// DeletePollTemplate deletes a poll template. The polls created from the template are kept.
+func (sa *Adapter) DeletePollTemplate(orgID string, id string) error {
+	filter := bson.M{"_id": id, "org_id": orgID}
+	res, err := sa.db.pollTemplates.DeleteOne(filter, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.DeletePollTemplate(%s) - %s", id, err)
+		return fmt.Errorf("error storage.Adapter.DeletePollTemplate(%s) - %s", id, err)
+	}
+	if res.DeletedCount == 0 {
+		return fmt.Errorf("error storage.Adapter.DeletePollTemplate(%s) - %w", id, model.ErrPollTemplateNotFound)
+	}
+	return nil
+}
+
+// DeletePollTemplatesWithAccountIDs deletes the poll templates created by the accounts
+func (sa *Adapter) DeletePollTemplatesWithAccountIDs(orgID string, accountsIDs []string) error {
+	filter := bson.M{"org_id": orgID, "user_id": bson.M{"$in": accountsIDs}}
+	_, err := sa.db.pollTemplates.DeleteMany(filter, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionDelete, "poll_templates", nil, err)
+	}
+	return nil
+}
+
+// ReplaceQuizScores replaces the scores of the users for a quiz poll, so a reopened and ended again poll is not scored twice
+func (sa *Adapter) ReplaceQuizScores(orgID string, pollID string, scores []model.QuizScore) error {
+	err := sa.PerformTransaction(func(ctx TransactionContext) error {
+		filter := bson.M{"org_id": orgID, "poll_id": pollID}
+		_, err := sa.db.quizScores.DeleteManyWithContext(ctx, filter, nil)
 		if err != nil {
-			sa.abortTransaction(sessionContext)
 			return err
 		}
 
-		err = transaction(sessionContext)
-		if err != nil {
-			sa.abortTransaction(sessionContext)
+		if len(scores) == 0 {
+			return nil
+		}
+		documents := make([]interface{}, len(scores))
+		for i, score := range scores {
+			documents[i] = score
+		}
+		_, err = sa.db.quizScores.InsertManyWithContext(ctx, documents, nil)
+		return err
+	})
+	if err != nil {
+		fmt.Printf("error storage.Adapter.ReplaceQuizScores(%s) - %s", pollID, err)
+		return fmt.Errorf("error storage.Adapter.ReplaceQuizScores(%s) - %s", pollID, err)
+	}
+	return nil
+}
+
+// GetQuizLeaderboard aggregates the scores of the users across the quiz polls of a group or a session, the highest score first
+func (sa *Adapter) GetQuizLeaderboard(orgID string, groupID *string, sessionID string, limit int) ([]model.QuizLeaderboardEntry, error) {
+	match := bson.D{primitive.E{Key: "org_id", Value: orgID}}
+	if groupID != nil {
+		match = append(match, primitive.E{Key: "group_id", Value: *groupID})
+	} else {
+		match = append(match, primitive.E{Key: "session_id", Value: sessionID})
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": match},
+		bson.M{"$group": bson.M{
+			"_id":             "$user_id",
+			"points":          bson.M{"$sum": "$points"},
+			"correct_answers": bson.M{"$sum": bson.M{"$cond": bson.A{"$correct", 1, 0}}},
+			"answered":        bson.M{"$sum": 1},
+		}},
+		bson.M{"$sort": bson.D{
+			primitive.E{Key: "points", Value: -1},
+			primitive.E{Key: "correct_answers", Value: -1},
+			primitive.E{Key: "_id", Value: 1},
+		}},
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.M{"$limit": limit})
+	}
+
+	results := []model.QuizLeaderboardEntry{}
+	err := sa.db.quizScores.Aggregate(pipeline, &results, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetQuizLeaderboard - %s", err)
+		return nil, fmt.Errorf("error storage.Adapter.GetQuizLeaderboard - %s", err)
+	}
+
+	return results, nil
+}
+
+// DeleteQuizScoresWithAccountIDs deletes the quiz scores of the accounts
+func (sa *Adapter) DeleteQuizScoresWithAccountIDs(orgID string, accountsIDs []string) error {
+	filter := bson.M{"org_id": orgID, "user_id": bson.M{"$in": accountsIDs}}
+	_, err := sa.db.quizScores.DeleteMany(filter, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionDelete, "quiz_scores", nil, err)
+	}
+	return nil
+}
+
+// GetPollSessions retrieves the sessions presented by the user, the newest first
+func (sa *Adapter) GetPollSessions(orgID string, userID string) ([]model.PollSession, error) {
+	filter := bson.M{"org_id": orgID, "user_id": userID}
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "date_created", Value: -1}})
+
+	results := []model.PollSession{}
+	err := sa.db.pollSessions.Find(filter, &results, findOptions)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetPollSessions - %s", err)
+		return nil, fmt.Errorf("error storage.Adapter.GetPollSessions - %s", err)
+	}
+
+	return results, nil
+}
+
+// GetPollSession retrieves a single poll session
+func (sa *Adapter) GetPollSession(orgID string, id string) (*model.PollSession, error) {
+	filter := bson.M{"_id": id, "org_id": orgID}
+	var entry model.PollSession
+	err := sa.db.pollSessions.FindOne(filter, &entry, nil)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("error storage.Adapter.GetPollSession(%s) - %w", id, model.ErrPollSessionNotFound)
+	}
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetPollSession(%s) - %s", id, err)
+		return nil, fmt.Errorf("error storage.Adapter.GetPollSession(%s) - %s", id, err)
+	}
+
+	return &entry, nil
+}
+
+// GetPollSessionByPin retrieves the session which has not ended with the PIN
+func (sa *Adapter) GetPollSessionByPin(orgID string, pin int) (*model.PollSession, error) {
+	filter := bson.M{"org_id": orgID, "active_pin": pin}
+	var entry model.PollSession
+	err := sa.db.pollSessions.FindOne(filter, &entry, nil)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("error storage.Adapter.GetPollSessionByPin(%d) - %w", pin, model.ErrPollSessionNotFound)
+	}
+	if err != nil {
+		fmt.Printf("error storage.Adapter.GetPollSessionByPin(%d) - %s", pin, err)
+		return nil, fmt.Errorf("error storage.Adapter.GetPollSessionByPin(%d) - %s", pin, err)
+	}
+
+	return &entry, nil
+}
+
+// CreatePollSession creates a poll session and links its polls in one transaction
+func (sa *Adapter) CreatePollSession(session model.PollSession) (*model.PollSession, error) {
+	err := sa.reservePollSessionPin(&session, func() error {
+		return sa.PerformTransaction(func(ctx TransactionContext) error {
+			_, err := sa.db.pollSessions.InsertOneWithContext(ctx, session)
+			if err != nil {
+				return err
+			}
+			return sa.setPollsSessionID(ctx, session.OrgID, session.ID, session.PollIDs)
+		})
+	})
+	if err != nil {
+		fmt.Printf("error storage.Adapter.CreatePollSession(%s) - %s", session.ID, err)
+		return nil, fmt.Errorf("error storage.Adapter.CreatePollSession(%s) - %w", session.ID, err)
+	}
+
+	return &session, nil
+}
+
+// UpdatePollSession updates the title, the polls and the PIN of a poll session and links its polls in one transaction.
+// The status is changed by UpdatePollSessionStatus only.
+func (sa *Adapter) UpdatePollSession(session model.PollSession) (*model.PollSession, error) {
+	now := time.Now().UTC()
+	session.DateUpdated = &now
+	filter := bson.M{"_id": session.ID, "org_id": session.OrgID}
+
+	err := sa.reservePollSessionPin(&session, func() error {
+		set := bson.D{
+			primitive.E{Key: "title", Value: session.Title},
+			primitive.E{Key: "poll_ids", Value: session.PollIDs},
+			primitive.E{Key: "pin", Value: session.Pin},
+			primitive.E{Key: "date_updated", Value: session.DateUpdated},
+		}
+		update := bson.D{}
+		if session.ActivePin != nil {
+			set = append(set, primitive.E{Key: "active_pin", Value: *session.ActivePin})
+		} else {
+			update = append(update, primitive.E{Key: "$unset", Value: bson.D{
+				primitive.E{Key: "active_pin", Value: ""},
+			}})
+		}
+		update = append(update, primitive.E{Key: "$set", Value: set})
+
+		return sa.PerformTransaction(func(ctx TransactionContext) error {
+			res, err := sa.db.pollSessions.UpdateOneWithContext(ctx, filter, update, nil)
+			if err != nil {
+				return err
+			}
+			if res.MatchedCount == 0 {
+				return model.ErrPollSessionNotFound
+			}
+			return sa.setPollsSessionID(ctx, session.OrgID, session.ID, session.PollIDs)
+		})
+	})
+	if err != nil {
+		fmt.Printf("error storage.Adapter.UpdatePollSession(%s) - %s", session.ID, err)
+		return nil, fmt.Errorf("error storage.Adapter.UpdatePollSession(%s) - %w", session.ID, err)
+	}
+
+	return &session, nil
+}
+
+// reservePollSessionPin reserves the session PIN among the sessions of the organization which have not ended while the session is stored.
+// The PIN uniqueness is guaranteed by the unique index on the active PIN. An allocated PIN is retried on collisions.
+func (sa *Adapter) reservePollSessionPin(session *model.PollSession, store func() error) error {
+	for attempt := 1; ; attempt++ {
+		if session.AutoPin {
+			pin, err := sa.allocateActivePin(sa.db.pollSessions, session.OrgID)
+			if err != nil {
+				return err
+			}
+			session.Pin = pin
+		}
+
+		session.ActivePin = nil
+		if session.Status != model.PollSessionStatusEnded && session.Pin > 0 {
+			pin := session.Pin
+			session.ActivePin = &pin
+		}
+
+		err := store()
+		if err == nil {
+			return nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
 			return err
 		}
+		if !session.AutoPin || attempt >= pollPinAllocationAttempts {
+			return fmt.Errorf("%w: %d", model.ErrPollPinInUse, session.Pin)
+		}
+	}
+}
+
+// UpdatePollSessionStatus changes the status and the current poll of a session if they have not been changed in the meantime.
+// Returns false if the session has been changed.
+func (sa *Adapter) UpdatePollSessionStatus(session model.PollSession, status string, currentPollID string) (bool, error) {
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: session.ID},
+		primitive.E{Key: "org_id", Value: session.OrgID},
+		primitive.E{Key: "status", Value: session.Status},
+	}
+	if len(session.CurrentPollID) > 0 {
+		filter = append(filter, primitive.E{Key: "current_poll_id", Value: session.CurrentPollID})
+	} else {
+		filter = append(filter, primitive.E{Key: "current_poll_id", Value: bson.M{"$exists": false}})
+	}
+
+	set := bson.D{
+		primitive.E{Key: "status", Value: status},
+		primitive.E{Key: "date_updated", Value: time.Now().UTC()},
+	}
+	unset := bson.D{}
+	if len(currentPollID) > 0 {
+		set = append(set, primitive.E{Key: "current_poll_id", Value: currentPollID})
+	} else {
+		unset = append(unset, primitive.E{Key: "current_poll_id", Value: ""})
+	}
+	if status == model.PollSessionStatusEnded {
+		// free the PIN
+		unset = append(unset, primitive.E{Key: "active_pin", Value: ""})
+	}
+	update := bson.D{primitive.E{Key: "$set", Value: set}}
+	if len(unset) > 0 {
+		update = append(update, primitive.E{Key: "$unset", Value: unset})
+	}
+
+	res, err := sa.db.pollSessions.UpdateOne(filter, update, nil)
+	if err != nil {
+		fmt.Printf("error storage.Adapter.UpdatePollSessionStatus(%s) - %s", session.ID, err)
+		return false, fmt.Errorf("error storage.Adapter.UpdatePollSessionStatus(%s) - %s", session.ID, err)
+	}
+
+	return res.MatchedCount > 0, nil
+}
+
+// setPollsSessionID sets the session of the polls in the transaction. The polls removed from the session are unlinked.
+func (sa *Adapter) setPollsSessionID(ctx TransactionContext, orgID string, sessionID string, pollIDs []string) error {
+	objIDs := make([]primitive.ObjectID, 0, len(pollIDs))
+	for _, pollID := range pollIDs {
+		objID, err := primitive.ObjectIDFromHex(pollID)
+		if err != nil {
+			return fmt.Errorf("%w: invalid poll id %s", model.ErrInvalidPollSession, pollID)
+		}
+		objIDs = append(objIDs, objID)
+	}
+
+	filter := bson.M{"org_id": orgID, "poll.session_id": sessionID, "_id": bson.M{"$nin": objIDs}}
+	_, err := sa.db.polls.UpdateManyWithContext(ctx, filter, bson.M{"$unset": bson.M{"poll.session_id": ""}}, nil)
+	if err != nil {
+		return err
+	}
+
+	filter = bson.M{"org_id": orgID, "_id": bson.M{"$in": objIDs}}
+	_, err = sa.db.polls.UpdateManyWithContext(ctx, filter, bson.M{"$set": bson.M{"poll.session_id": sessionID}}, nil)
+	return err
+}
 
-		err = sessionContext.CommitTransaction(sessionContext)
+// DeletePollSession deletes a poll session. The polls are kept and unlinked from the session.
+func (sa *Adapter) DeletePollSession(orgID string, id string) error {
+	err := sa.PerformTransaction(func(ctx TransactionContext) error {
+		res, err := sa.db.pollSessions.DeleteOneWithContext(ctx, bson.M{"_id": id, "org_id": orgID}, nil)
 		if err != nil {
-			sa.abortTransaction(sessionContext)
 			return err
 		}
-		return nil
+		if res.DeletedCount == 0 {
+			return model.ErrPollSessionNotFound
+		}
+
+		filter := bson.M{"org_id": orgID, "poll.session_id": id}
+		_, err = sa.db.polls.UpdateManyWithContext(ctx, filter, bson.M{"$unset": bson.M{"poll.session_id": ""}}, nil)
+		return err
 	})
+	if err != nil {
+		fmt.Printf("error storage.Adapter.DeletePollSession(%s) - %s", id, err)
+		return fmt.Errorf("error storage.Adapter.DeletePollSession(%s) - %w", id, err)
+	}
+	return nil
+}
 
-	return err
+// DeletePollSessionsWithAccountIDs deletes the poll sessions presented by the accounts
+func (sa *Adapter) DeletePollSessionsWithAccountIDs(orgID string, accountsIDs []string) error {
+	filter := bson.M{"org_id": orgID, "user_id": bson.M{"$in": accountsIDs}}
+	_, err := sa.db.pollSessions.DeleteMany(filter, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionDelete, "poll_sessions", nil, err)
+	}
+	return nil
 }
 
-func (sa *Adapter) abortTransaction(sessionContext mongo.SessionContext) {
-	err := sessionContext.AbortTransaction(sessionContext)
+// GetAllPolls gets all polls
+func (sa *Adapter) GetAllPolls() ([]model.Poll, error) {
+	filter := bson.M{}
+	var results []model.Poll
+	err := sa.db.polls.Find(filter, &results, nil)
 	if err != nil {
-		log.Printf("error aborting a transaction - %s\n", err)
+		fmt.Printf("error storage.Adapter.GetAllPolls - %s", err)
+		return nil, fmt.Errorf("error storage.Adapter.GetAllPolls - %s", err)
 	}
+
+	return results, nil
+}
+
+// PerformTransaction performs a transaction. The transaction is retried when it fails with a transient error,
+// e.g. a write conflict with a concurrent transaction, so it must not have side effects outside the database.
+// The transient errors are detected by their labels, which are kept by the errors wrapped with %w only.
+func (sa *Adapter) PerformTransaction(transaction func(context TransactionContext) error) error {
+	// transaction
+	err := sa.db.dbClient.UseSession(context.Background(), func(sessionContext mongo.SessionContext) error {
+		_, err := sessionContext.WithTransaction(sessionContext, func(sessionContext mongo.SessionContext) (interface{}, error) {
+			return nil, transaction(sessionContext)
+		})
+		return err
+	})
+
+	return err
 }
@@ -56,6 +56,32 @@ func (collWrapper *collectionWrapper) FindWithContext(ctx context.Context, filte
 	return err
 }
 
+// FindEach decodes the found documents one by one, so the results are never loaded in memory at once.
+// There is no timeout for the whole iteration as it depends on how fast the handler consumes the documents.
+func (collWrapper *collectionWrapper) FindEach(filter interface{}, findOptions *options.FindOptions, handler func(cur *mongo.Cursor) error) error {
+	ctx := context.Background()
+
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	findCtx, cancel := context.WithTimeout(ctx, collWrapper.database.mongoTimeout)
+	defer cancel()
+	cur, err := collWrapper.coll.Find(findCtx, filter, findOptions)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		err = handler(cur)
+		if err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
 func (collWrapper *collectionWrapper) FindOne(filter interface{}, result interface{}, findOptions *options.FindOneOptions) error {
 	return collWrapper.FindOneWithContext(context.Background(), filter, result, findOptions)
 }
@@ -211,6 +237,44 @@ func (collWrapper *collectionWrapper) UpdateOneWithContext(ctx context.Context,
 	return updateResult, nil
 }
 
+func (collWrapper *collectionWrapper) FindOneAndUpdate(filter interface{}, update interface{}, result interface{}, opts *options.FindOneAndUpdateOptions) error {
+	return collWrapper.FindOneAndUpdateWithContext(context.Background(), filter, update, result, opts)
+}
+
+func (collWrapper *collectionWrapper) FindOneAndUpdateWithContext(ctx context.Context, filter interface{}, update interface{}, result interface{}, opts *options.FindOneAndUpdateOptions) error {
+	ctx, cancel := context.WithTimeout(ctx, collWrapper.database.mongoTimeout)
+	defer cancel()
+
+	singleResult := collWrapper.coll.FindOneAndUpdate(ctx, filter, update, opts)
+	if singleResult.Err() != nil {
+		return singleResult.Err()
+	}
+	err := singleResult.Decode(result)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (collWrapper *collectionWrapper) FindOneAndDelete(filter interface{}, result interface{}, opts *options.FindOneAndDeleteOptions) error {
+	return collWrapper.FindOneAndDeleteWithContext(context.Background(), filter, result, opts)
+}
+
+func (collWrapper *collectionWrapper) FindOneAndDeleteWithContext(ctx context.Context, filter interface{}, result interface{}, opts *options.FindOneAndDeleteOptions) error {
+	ctx, cancel := context.WithTimeout(ctx, collWrapper.database.mongoTimeout)
+	defer cancel()
+
+	singleResult := collWrapper.coll.FindOneAndDelete(ctx, filter, opts)
+	if singleResult.Err() != nil {
+		return singleResult.Err()
+	}
+	err := singleResult.Decode(result)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (collWrapper *collectionWrapper) UpdateMany(filter interface{}, update interface{}, opts *options.UpdateOptions) (*mongo.UpdateResult, error) {
 	return collWrapper.UpdateManyWithContext(context.Background(), filter, update, opts)
 }
@@ -243,6 +307,21 @@ func (collWrapper *collectionWrapper) CountDocuments(filter interface{}) (int64,
 	return count, nil
 }
 
+func (collWrapper *collectionWrapper) Distinct(fieldName string, filter interface{}) ([]interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), collWrapper.database.mongoTimeout)
+	defer cancel()
+
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	values, err := collWrapper.coll.Distinct(ctx, fieldName, filter)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
 func (collWrapper *collectionWrapper) Watch(pipeline interface{}) error {
 	if pipeline == nil {
 		pipeline = []bson.M{}
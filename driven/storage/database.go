@@ -28,6 +28,11 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+const (
+	pollsTextIndex   = "poll_text_search"
+	surveysTextIndex = "survey_text_search"
+)
+
 // CollectionListener listens for collection updates
 type CollectionListener interface {
 	OnCollectionUpdated(name string, record map[string]interface{})
@@ -45,6 +50,10 @@ type database struct {
 	logger   *logs.Logger
 
 	polls           *collectionWrapper
+	pollVotes       *collectionWrapper
+	pollTemplates   *collectionWrapper
+	quizScores      *collectionWrapper
+	pollSessions    *collectionWrapper
 	settings        *collectionWrapper
 	surveys         *collectionWrapper
 	surveyResponses *collectionWrapper
@@ -92,6 +101,30 @@ func (m *database) start() error {
 	}
 	go polls.Watch(nil)
 
+	pollVotes := &collectionWrapper{database: m, coll: db.Collection("poll_votes")}
+	err = m.applyPollVotesChecks(pollVotes)
+	if err != nil {
+		return err
+	}
+
+	pollTemplates := &collectionWrapper{database: m, coll: db.Collection("poll_templates")}
+	err = m.applyPollTemplatesChecks(pollTemplates)
+	if err != nil {
+		return err
+	}
+
+	quizScores := &collectionWrapper{database: m, coll: db.Collection("quiz_scores")}
+	err = m.applyQuizScoresChecks(quizScores)
+	if err != nil {
+		return err
+	}
+
+	pollSessions := &collectionWrapper{database: m, coll: db.Collection("poll_sessions")}
+	err = m.applyPollSessionsChecks(pollSessions)
+	if err != nil {
+		return err
+	}
+
 	surveys := &collectionWrapper{database: m, coll: db.Collection("surveys")}
 	err = m.applySurveysChecks(surveys)
 	if err != nil {
@@ -111,6 +144,10 @@ func (m *database) start() error {
 	}
 
 	m.polls = polls
+	m.pollVotes = pollVotes
+	m.pollTemplates = pollTemplates
+	m.quizScores = quizScores
+	m.pollSessions = pollSessions
 	m.settings = settings
 	m.surveys = surveys
 	m.surveyResponses = surveyResponses
@@ -186,22 +223,182 @@ func (m *database) applyPollsChecks(posts *collectionWrapper) error {
 		}
 	}
 
-	if indexMapping["responses.userid_1_poll.status_1__id_1"] == nil {
+	// the votes are stored in the poll votes collection
+	if indexMapping["responses.userid_1_poll.status_1__id_1"] != nil {
+		err := posts.DropIndex("responses.userid_1_poll.status_1__id_1")
+		if err != nil {
+			return err
+		}
+	}
+
+	if indexMapping["poll.status_1_poll.start_at_1"] == nil {
 		err := posts.AddIndex(
 			bson.D{
-				primitive.E{Key: "responses.userid", Value: 1},
 				primitive.E{Key: "poll.status", Value: 1},
-				primitive.E{Key: "_id", Value: 1},
+				primitive.E{Key: "poll.start_at", Value: 1},
 			}, false)
 		if err != nil {
 			return err
 		}
 	}
 
+	if indexMapping["poll.status_1_poll.end_at_1"] == nil {
+		err := posts.AddIndex(
+			bson.D{
+				primitive.E{Key: "poll.status", Value: 1},
+				primitive.E{Key: "poll.end_at", Value: 1},
+			}, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if indexMapping["poll.co_owners_1"] == nil {
+		err := posts.AddIndex(
+			bson.D{
+				primitive.E{Key: "poll.co_owners", Value: 1},
+			}, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if indexMapping["poll.series_id_1"] == nil {
+		err := posts.AddIndex(
+			bson.D{
+				primitive.E{Key: "poll.series_id", Value: 1},
+			}, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if indexMapping["next_instance_at_1"] == nil {
+		err := posts.AddIndexWithOptions(
+			bson.D{
+				primitive.E{Key: "next_instance_at", Value: 1},
+			}, options.Index().SetSparse(true))
+		if err != nil {
+			return err
+		}
+	}
+
+	// the PIN is unique among the active polls of the organization
+	if indexMapping["org_id_1_active_pin_1"] == nil {
+		err := posts.AddIndexWithOptions(
+			bson.D{
+				primitive.E{Key: "org_id", Value: 1},
+				primitive.E{Key: "active_pin", Value: 1},
+			}, options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"active_pin": bson.M{"$exists": true}}))
+		if err != nil {
+			return err
+		}
+	}
+
 	log.Println("polls checks passed")
 	return nil
 }
 
+func (m *database) applyPollVotesChecks(pollVotes *collectionWrapper) error {
+	log.Println("apply poll votes checks.....")
+
+	indexes, _ := pollVotes.ListIndexes()
+	indexMapping := map[string]interface{}{}
+	if indexes != nil {
+
+		for _, index := range indexes {
+			name := index["name"].(string)
+			indexMapping[name] = index
+		}
+	}
+
+	if indexMapping["poll_id_1_user_id_1"] == nil {
+		err := pollVotes.AddIndex(
+			bson.D{
+				primitive.E{Key: "poll_id", Value: 1},
+				primitive.E{Key: "user_id", Value: 1},
+			}, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	if indexMapping["org_id_1_user_id_1"] == nil {
+		err := pollVotes.AddIndex(
+			bson.D{
+				primitive.E{Key: "org_id", Value: 1},
+				primitive.E{Key: "user_id", Value: 1},
+			}, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Println("poll votes checks passed")
+	return nil
+}
+
+func (m *database) applyPollTemplatesChecks(pollTemplates *collectionWrapper) error {
+	log.Println("apply poll templates checks.....")
+
+	err := pollTemplates.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "user_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	err = pollTemplates.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "group_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	log.Println("poll templates passed")
+	return nil
+}
+
+func (m *database) applyQuizScoresChecks(quizScores *collectionWrapper) error {
+	log.Println("apply quiz scores checks.....")
+
+	err := quizScores.AddIndex(bson.D{primitive.E{Key: "poll_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	err = quizScores.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "group_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	err = quizScores.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "session_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	log.Println("quiz scores passed")
+	return nil
+}
+
+func (m *database) applyPollSessionsChecks(pollSessions *collectionWrapper) error {
+	log.Println("apply poll sessions checks.....")
+
+	err := pollSessions.AddIndex(bson.D{primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "user_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	// the PIN is unique among the sessions of the organization which have not ended
+	err = pollSessions.AddIndexWithOptions(
+		bson.D{
+			primitive.E{Key: "org_id", Value: 1},
+			primitive.E{Key: "active_pin", Value: 1},
+		}, options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"active_pin": bson.M{"$exists": true}}))
+	if err != nil {
+		return err
+	}
+
+	log.Println("poll sessions passed")
+	return nil
+}
+
 func (m *database) applySettingsChecks(posts *collectionWrapper) error {
 	log.Println("apply settings checks.....")
 
@@ -247,6 +444,13 @@ func (m *database) applySurveysChecks(surveys *collectionWrapper) error {
 		return err
 	}
 
+	//the title is more relevant than the questions
+	err = surveys.AddIndexWithOptions(bson.D{primitive.E{Key: "title", Value: "text"}, primitive.E{Key: "search_texts", Value: "text"}},
+		options.Index().SetName(surveysTextIndex).SetWeights(bson.M{"title": 3, "search_texts": 1}))
+	if err != nil {
+		return err
+	}
+
 	log.Println("surveys passed")
 	return nil
 }
@@ -17,9 +17,12 @@ package storage
 import (
 	"fmt"
 	"log"
+	"polls/core/model"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // RecordCount wraps count aggregation
@@ -106,3 +109,212 @@ func (sa *Adapter) migrateNonOrgRecords(wrapper *collectionWrapper) error {
 	}
 	return nil
 }
+
+// backfillPollCounters calculates the vote counters for the polls created before the counters were introduced
+func (sa *Adapter) backfillPollCounters() error {
+	filter := bson.D{
+		primitive.E{Key: "counters", Value: bson.M{"$exists": false}},
+	}
+
+	var polls []model.Poll
+	err := sa.db.polls.Find(filter, &polls, nil)
+	if err != nil {
+		log.Printf("error storage.Adapter.backfillPollCounters() - %s", err)
+		return fmt.Errorf("error storage.Adapter.backfillPollCounters() - %s", err)
+	}
+	if len(polls) == 0 {
+		return nil
+	}
+
+	log.Printf("backfillPollCounters started for %d polls", len(polls))
+	for _, poll := range polls {
+		counters := model.CountPollVotes(poll.Responses, poll.Results)
+
+		pollFilter := bson.D{
+			primitive.E{Key: "_id", Value: poll.ID},
+			primitive.E{Key: "counters", Value: bson.M{"$exists": false}},
+		}
+		update := bson.D{
+			primitive.E{Key: "$set", Value: bson.D{
+				primitive.E{Key: "counters", Value: counters},
+			}},
+		}
+
+		_, err = sa.db.polls.UpdateOne(pollFilter, update, nil)
+		if err != nil {
+			log.Printf("error storage.Adapter.backfillPollCounters() - %s", err)
+			return fmt.Errorf("error storage.Adapter.backfillPollCounters() - %s", err)
+		}
+	}
+	log.Printf("backfillPollCounters ended")
+
+	return nil
+}
+
+// migratePollVotes moves the votes embedded in the poll documents to the poll votes collection.
+// The polls are processed one by one and the embedded votes are removed once they are moved, so it can be safely restarted.
+func (sa *Adapter) migratePollVotes() error {
+	filter := bson.D{
+		primitive.E{Key: "responses.0", Value: bson.M{"$exists": true}},
+	}
+
+	count := 0
+	for {
+		var poll model.Poll
+		err := sa.db.polls.FindOne(filter, &poll, nil)
+		if err == mongo.ErrNoDocuments {
+			break
+		}
+		if err != nil {
+			log.Printf("error storage.Adapter.migratePollVotes() - %s", err)
+			return fmt.Errorf("error storage.Adapter.migratePollVotes() - %s", err)
+		}
+
+		err = sa.migratePollResponses(poll)
+		if err != nil {
+			log.Printf("error storage.Adapter.migratePollVotes() - %s", err)
+			return fmt.Errorf("error storage.Adapter.migratePollVotes() - %s", err)
+		}
+		count++
+	}
+
+	if count > 0 {
+		log.Printf("migrate votes of %d polls successfully", count)
+	}
+	return nil
+}
+
+// migratePollResponses moves the embedded votes of a poll to the poll votes collection
+func (sa *Adapter) migratePollResponses(poll model.Poll) error {
+	//group the votes by user keeping their order
+	userIDs := []string{}
+	userVotes := map[string][]model.PollVote{}
+	for _, vote := range poll.Responses {
+		if _, ok := userVotes[vote.UserID]; !ok {
+			userIDs = append(userIDs, vote.UserID)
+		}
+		userVotes[vote.UserID] = append(userVotes[vote.UserID], vote)
+	}
+
+	for _, userID := range userIDs {
+		votes := userVotes[userID]
+
+		filter := bson.D{
+			primitive.E{Key: "poll_id", Value: poll.ID},
+			primitive.E{Key: "user_id", Value: userID},
+		}
+		update := bson.D{
+			primitive.E{Key: "$setOnInsert", Value: bson.D{
+				primitive.E{Key: "_id", Value: primitive.NewObjectID()},
+				primitive.E{Key: "date_created", Value: votes[0].Created},
+			}},
+			primitive.E{Key: "$set", Value: bson.D{
+				primitive.E{Key: "org_id", Value: poll.OrgID},
+				primitive.E{Key: "votes", Value: votes},
+				primitive.E{Key: "date_updated", Value: votes[len(votes)-1].Created},
+			}},
+		}
+		_, err := sa.db.pollVotes.UpdateOne(filter, update, options.Update().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+	}
+
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: poll.ID},
+	}
+	update := bson.D{
+		primitive.E{Key: "$unset", Value: bson.D{
+			primitive.E{Key: "responses", Value: ""},
+		}},
+	}
+	_, err := sa.db.polls.UpdateOne(filter, update, nil)
+	return err
+}
+
+// reserveActivePollPins reserves the PINs of the active polls created before the PINs were reserved.
+// If several active polls share a PIN, the oldest poll keeps it and the others stay unreserved until they end.
+func (sa *Adapter) reserveActivePollPins() error {
+	filter := bson.D{
+		primitive.E{Key: "poll.status", Value: bson.M{"$ne": PollStatusTerminated}},
+		primitive.E{Key: "poll.pin", Value: bson.M{"$gt": 0}},
+		primitive.E{Key: "active_pin", Value: bson.M{"$exists": false}},
+	}
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "_id", Value: 1}})
+
+	var polls []model.Poll
+	err := sa.db.polls.Find(filter, &polls, findOptions)
+	if err != nil {
+		log.Printf("error storage.Adapter.reserveActivePollPins() - %s", err)
+		return fmt.Errorf("error storage.Adapter.reserveActivePollPins() - %s", err)
+	}
+
+	reserved := 0
+	for _, poll := range polls {
+		pollFilter := bson.D{
+			primitive.E{Key: "_id", Value: poll.ID},
+			primitive.E{Key: "poll.status", Value: bson.M{"$ne": PollStatusTerminated}},
+		}
+		update := bson.D{
+			primitive.E{Key: "$set", Value: bson.D{
+				primitive.E{Key: "active_pin", Value: poll.Pin},
+			}},
+		}
+
+		_, err = sa.db.polls.UpdateOne(pollFilter, update, nil)
+		if mongo.IsDuplicateKeyError(err) {
+			log.Printf("storage.Adapter.reserveActivePollPins() - pin %d of poll %s is used by another active poll", poll.Pin, poll.ID.Hex())
+			continue
+		}
+		if err != nil {
+			log.Printf("error storage.Adapter.reserveActivePollPins() - %s", err)
+			return fmt.Errorf("error storage.Adapter.reserveActivePollPins() - %s", err)
+		}
+		reserved++
+	}
+
+	if reserved > 0 {
+		log.Printf("reserve pins of %d active polls successfully", reserved)
+	}
+	return nil
+}
+
+// backfillSurveySearchTexts sets the texts indexed for the full-text search of the surveys created before the search was introduced
+func (sa *Adapter) backfillSurveySearchTexts() error {
+	filter := bson.D{
+		primitive.E{Key: "search_texts", Value: bson.M{"$exists": false}},
+		primitive.E{Key: "data", Value: bson.M{"$ne": nil}},
+	}
+
+	var surveys []model.Survey
+	err := sa.db.surveys.Find(filter, &surveys, nil)
+	if err != nil {
+		log.Printf("error storage.Adapter.backfillSurveySearchTexts() - %s", err)
+		return fmt.Errorf("error storage.Adapter.backfillSurveySearchTexts() - %s", err)
+	}
+	if len(surveys) == 0 {
+		return nil
+	}
+
+	log.Printf("backfillSurveySearchTexts started for %d surveys", len(surveys))
+	for _, survey := range surveys {
+		surveyFilter := bson.D{
+			primitive.E{Key: "_id", Value: survey.ID},
+			primitive.E{Key: "search_texts", Value: bson.M{"$exists": false}},
+		}
+		update := bson.D{
+			primitive.E{Key: "$set", Value: bson.D{
+				primitive.E{Key: "search_texts", Value: survey.GetSearchTexts()},
+			}},
+		}
+
+		_, err = sa.db.surveys.UpdateOne(surveyFilter, update, nil)
+		if err != nil {
+			log.Printf("error storage.Adapter.backfillSurveySearchTexts() - %s", err)
+			return fmt.Errorf("error storage.Adapter.backfillSurveySearchTexts() - %s", err)
+		}
+	}
+	log.Printf("backfillSurveySearchTexts ended")
+
+	return nil
+}
@@ -87,12 +87,39 @@ func (we Adapter) Start() {
 	apiRouter.HandleFunc("/polls/load", we.userAuthWrapFunc(we.apisHandler.LoadPolls)).Methods("POST")
 	apiRouter.HandleFunc("/polls", we.userAuthWrapFunc(we.apisHandler.CreatePoll)).Methods("POST")
 	apiRouter.HandleFunc("/polls/{id}", we.userAuthWrapFunc(we.apisHandler.GetPoll)).Methods("GET")
+	apiRouter.HandleFunc("/polls/by-pin/{pin}", we.userAuthWrapFunc(we.apisHandler.GetPollByPin)).Methods("GET")
 	apiRouter.HandleFunc("/polls/{id}", we.userAuthWrapFunc(we.apisHandler.UpdatePoll)).Methods("PUT")
 	apiRouter.HandleFunc("/polls/{id}", we.userAuthWrapFunc(we.apisHandler.DeletePoll)).Methods("DELETE")
 	apiRouter.HandleFunc("/polls/{id}/events", we.userAuthWrapFunc(we.apisHandler.GetPollEvents)).Methods("GET")
 	apiRouter.HandleFunc("/polls/{id}/vote", we.userAuthWrapFunc(we.apisHandler.VotePoll)).Methods("PUT")
+	apiRouter.HandleFunc("/polls/{id}/vote", we.userAuthWrapFunc(we.apisHandler.RetractPollVote)).Methods("DELETE")
+	apiRouter.HandleFunc("/polls/{id}/results/export", we.userAuthWrapFunc(we.apisHandler.ExportPollResults)).Methods("GET")
+	apiRouter.HandleFunc("/polls/{id}/text-answers", we.userAuthWrapFunc(we.apisHandler.GetPollTextAnswers)).Methods("GET")
+	apiRouter.HandleFunc("/polls/{id}/text-answers/{answer_id}", we.userAuthWrapFunc(we.apisHandler.ModeratePollTextAnswer)).Methods("PUT")
 	apiRouter.HandleFunc("/polls/{id}/start", we.userAuthWrapFunc(we.apisHandler.StartPoll)).Methods("PUT")
 	apiRouter.HandleFunc("/polls/{id}/end", we.userAuthWrapFunc(we.apisHandler.EndPoll)).Methods("PUT")
+	apiRouter.HandleFunc("/polls/{id}/pause", we.userAuthWrapFunc(we.apisHandler.PausePoll)).Methods("PUT")
+	apiRouter.HandleFunc("/polls/{id}/reopen", we.userAuthWrapFunc(we.apisHandler.ReopenPoll)).Methods("PUT")
+	apiRouter.HandleFunc("/polls/{id}/clone", we.userAuthWrapFunc(we.apisHandler.ClonePoll)).Methods("POST")
+	apiRouter.HandleFunc("/polls/{id}/series", we.userAuthWrapFunc(we.apisHandler.GetPollSeries)).Methods("GET")
+	apiRouter.HandleFunc("/polls/{id}/co-owners/{user_id}", we.userAuthWrapFunc(we.apisHandler.AddPollCoOwner)).Methods("PUT")
+	apiRouter.HandleFunc("/polls/{id}/co-owners/{user_id}", we.userAuthWrapFunc(we.apisHandler.RemovePollCoOwner)).Methods("DELETE")
+	apiRouter.HandleFunc("/poll-templates", we.userAuthWrapFunc(we.apisHandler.GetPollTemplates)).Methods("GET")
+	apiRouter.HandleFunc("/poll-templates/{id}", we.userAuthWrapFunc(we.apisHandler.GetPollTemplate)).Methods("GET")
+	apiRouter.HandleFunc("/poll-templates", we.userAuthWrapFunc(we.apisHandler.CreatePollTemplate)).Methods("POST")
+	apiRouter.HandleFunc("/poll-templates/{id}", we.userAuthWrapFunc(we.apisHandler.UpdatePollTemplate)).Methods("PUT")
+	apiRouter.HandleFunc("/poll-templates/{id}", we.userAuthWrapFunc(we.apisHandler.DeletePollTemplate)).Methods("DELETE")
+	apiRouter.HandleFunc("/poll-sessions", we.userAuthWrapFunc(we.apisHandler.GetPollSessions)).Methods("GET")
+	apiRouter.HandleFunc("/poll-sessions", we.userAuthWrapFunc(we.apisHandler.CreatePollSession)).Methods("POST")
+	apiRouter.HandleFunc("/poll-sessions/by-pin/{pin}", we.userAuthWrapFunc(we.apisHandler.GetPollSessionByPin)).Methods("GET")
+	apiRouter.HandleFunc("/poll-sessions/{id}", we.userAuthWrapFunc(we.apisHandler.GetPollSession)).Methods("GET")
+	apiRouter.HandleFunc("/poll-sessions/{id}", we.userAuthWrapFunc(we.apisHandler.UpdatePollSession)).Methods("PUT")
+	apiRouter.HandleFunc("/poll-sessions/{id}", we.userAuthWrapFunc(we.apisHandler.DeletePollSession)).Methods("DELETE")
+	apiRouter.HandleFunc("/poll-sessions/{id}/next", we.userAuthWrapFunc(we.apisHandler.AdvancePollSession)).Methods("PUT")
+	apiRouter.HandleFunc("/poll-sessions/{id}/end", we.userAuthWrapFunc(we.apisHandler.EndPollSession)).Methods("PUT")
+	apiRouter.HandleFunc("/poll-sessions/{id}/events", we.userAuthWrapFunc(we.apisHandler.GetPollSessionEvents)).Methods("GET")
+	apiRouter.HandleFunc("/quiz-leaderboard", we.userAuthWrapFunc(we.apisHandler.GetQuizLeaderboard)).Methods("GET")
+	apiRouter.HandleFunc("/surveys", we.userAuthWrapFunc(we.apisHandler.GetSurveys)).Methods("GET")
 	apiRouter.HandleFunc("/surveys/{id}", we.userAuthWrapFunc(we.apisHandler.GetSurvey)).Methods("GET")
 	apiRouter.HandleFunc("/surveys", we.userAuthWrapFunc(we.apisHandler.CreateSurvey)).Methods("POST")
 	apiRouter.HandleFunc("/surveys/{id}", we.userAuthWrapFunc(we.apisHandler.UpdateSurvey)).Methods("PUT")
@@ -109,6 +136,7 @@ func (we Adapter) Start() {
 	// handle admin apis
 	adminRouter := apiRouter.PathPrefix("/admin").Subrouter()
 
+	adminRouter.HandleFunc("/surveys", we.adminAuthWrapFunc(we.adminApisHandler.GetSurveys)).Methods("GET")
 	adminRouter.HandleFunc("/surveys/{id}", we.adminAuthWrapFunc(we.adminApisHandler.GetSurvey)).Methods("GET")
 	adminRouter.HandleFunc("/surveys", we.adminAuthWrapFunc(we.adminApisHandler.CreateSurvey)).Methods("POST")
 	adminRouter.HandleFunc("/surveys/{id}", we.adminAuthWrapFunc(we.adminApisHandler.UpdateSurvey)).Methods("PUT")
@@ -118,6 +146,16 @@ func (we Adapter) Start() {
 	adminRouter.HandleFunc("/alert-contacts", we.adminAuthWrapFunc(we.adminApisHandler.CreateAlertContact)).Methods("POST")
 	adminRouter.HandleFunc("/alert-contacts/{id}", we.adminAuthWrapFunc(we.adminApisHandler.UpdateAlertContact)).Methods("PUT")
 	adminRouter.HandleFunc("/alert-contacts/{id}", we.adminAuthWrapFunc(we.adminApisHandler.DeleteAlertContact)).Methods("DELETE")
+	adminRouter.HandleFunc("/stadiums", we.adminAuthWrapFunc(we.adminApisHandler.GetStadiums)).Methods("GET")
+	adminRouter.HandleFunc("/stadiums/{id}", we.adminAuthWrapFunc(we.adminApisHandler.GetStadium)).Methods("GET")
+	adminRouter.HandleFunc("/stadiums", we.adminAuthWrapFunc(we.adminApisHandler.CreateStadium)).Methods("POST")
+	adminRouter.HandleFunc("/stadiums/{id}", we.adminAuthWrapFunc(we.adminApisHandler.UpdateStadium)).Methods("PUT")
+	adminRouter.HandleFunc("/stadiums/{id}", we.adminAuthWrapFunc(we.adminApisHandler.DeleteStadium)).Methods("DELETE")
+	adminRouter.HandleFunc("/polls", we.adminAuthWrapFunc(we.adminApisHandler.GetPolls)).Methods("GET")
+	adminRouter.HandleFunc("/polls/{id}", we.adminAuthWrapFunc(we.adminApisHandler.GetPoll)).Methods("GET")
+	adminRouter.HandleFunc("/polls/{id}", we.adminAuthWrapFunc(we.adminApisHandler.UpdatePoll)).Methods("PUT")
+	adminRouter.HandleFunc("/polls/{id}", we.adminAuthWrapFunc(we.adminApisHandler.DeletePoll)).Methods("DELETE")
+	adminRouter.HandleFunc("/polls/{id}/end", we.adminAuthWrapFunc(we.adminApisHandler.EndPoll)).Methods("PUT")
 
 	// BB internal APIs
 	bbsRouter := apiRouter.PathPrefix("/bbs").Subrouter()
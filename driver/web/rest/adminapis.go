@@ -23,6 +23,7 @@ import (
 	"polls/core/model"
 
 	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // AdminApisHandler handles the rest Admin APIs implementation
@@ -31,6 +32,40 @@ type AdminApisHandler struct {
 	config *model.Config
 }
 
+// GetSurveys Retrieves the surveys by filter params
+// @Description Retrieves the surveys of the app by filter params. The surveys matching the search are sorted by relevance, the other ones newest first.
+// @Tags Admin
+// @ID AdminGetSurveys
+// @Param search query string false "Full-text search over the title and the questions"
+// @Param types query string false "Comma separated survey types"
+// @Param offset query integer false "Offset"
+// @Param limit query integer false "Limit"
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.Survey
+// @Failure 401
+// @Security UserAuth
+// @Router /surveys [get]
+func (h AdminApisHandler) GetSurveys(user *model.User, w http.ResponseWriter, r *http.Request) {
+	resData, err := h.app.Services.GetSurveys(user, getSurveysFilter(r))
+	if err != nil {
+		log.Printf("Error on adminapis.GetSurveys: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Printf("Error on adminapis.GetSurveys: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
 // GetSurvey Retrieves a Survey by id
 // @Description Retrieves a Survey by id
 // @Tags Admin
@@ -380,3 +415,399 @@ func (h AdminApisHandler) DeleteAlertContact(user *model.User, w http.ResponseWr
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 }
+
+// GetStadiums Retrieves all stadiums
+// @Description Retrieves all stadiums
+// @Tags Admin
+// @ID GetStadiums
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.Stadium
+// @Failure 401
+// @Security UserAuth
+// @Router /stadiums [get]
+func (h AdminApisHandler) GetStadiums(user *model.User, w http.ResponseWriter, r *http.Request) {
+	resData, err := h.app.Services.GetStadiums(user)
+	if err != nil {
+		log.Printf("Error on apis.GetStadiums: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Printf("Error on apis.GetStadiums: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetStadium Retrieves a stadium by id
+// @Description Retrieves a stadium by id
+// @Tags Admin
+// @ID GetStadium
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.Stadium
+// @Failure 401
+// @Failure 404
+// @Security UserAuth
+// @Router /stadiums/{id} [get]
+func (h AdminApisHandler) GetStadium(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.GetStadium(user, id)
+	if err != nil {
+		log.Printf("Error on apis.GetStadium(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Printf("Error on apis.GetStadium(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// CreateStadium Creates a new stadium
+// @Description Creates a new stadium. The polls created with the stadium key get its default settings.
+// @Tags Admin
+// @ID CreateStadium
+// @Param data body model.Stadium true "body json"
+// @Accept json
+// @Success 200 {object} model.Stadium
+// @Failure 400
+// @Failure 401
+// @Failure 409
+// @Security UserAuth
+// @Router /stadiums [post]
+func (h AdminApisHandler) CreateStadium(user *model.User, w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on apis.CreateStadium: %s", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var item model.Stadium
+	err = json.Unmarshal(data, &item)
+	if err != nil {
+		log.Printf("Error on apis.CreateStadium: %s", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	createdItem, err := h.app.Services.CreateStadium(user, item)
+	if err != nil {
+		log.Printf("Error on apis.CreateStadium: %s", err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	jsonData, err := json.Marshal(createdItem)
+	if err != nil {
+		log.Printf("Error on apis.CreateStadium: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// UpdateStadium Updates a stadium with the specified id
+// @Description Updates a stadium with the specified id. The existing polls keep the settings applied when they were created.
+// @Tags Admin
+// @ID UpdateStadium
+// @Param data body model.Stadium true "body json"
+// @Accept json
+// @Produce json
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 409
+// @Security UserAuth
+// @Router /stadiums/{id} [put]
+func (h AdminApisHandler) UpdateStadium(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on apis.UpdateStadium(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var item model.Stadium
+	err = json.Unmarshal(data, &item)
+	if err != nil {
+		log.Printf("Error on apis.UpdateStadium(%s): %s", id, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.app.Services.UpdateStadium(user, id, item)
+	if err != nil {
+		log.Printf("Error on apis.UpdateStadium(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteStadium Deletes a stadium with the specified id
+// @Description Deletes a stadium with the specified id
+// @Tags Admin
+// @ID DeleteStadium
+// @Success 200
+// @Failure 401
+// @Failure 404
+// @Security UserAuth
+// @Router /stadiums/{id} [delete]
+func (h AdminApisHandler) DeleteStadium(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	err := h.app.Services.DeleteStadium(user, id)
+	if err != nil {
+		log.Printf("Error on apis.DeleteStadium(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetPolls Retrieves all polls of the organization by filter params
+// @Description Retrieves all polls of the organization regardless of their owner and members, newest first
+// @Tags Admin
+// @ID AdminGetPolls
+// @Param user_ids query string false "Comma separated creator ids"
+// @Param group_ids query string false "Comma separated group ids"
+// @Param statuses query string false "Comma separated statuses"
+// @Param stadiums query string false "Comma separated stadium keys"
+// @Param search query string false "Full-text search over the question and the options"
+// @Param pin query integer false "PIN"
+// @Param offset query integer false "Offset"
+// @Param limit query integer false "Limit"
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.PollResult
+// @Failure 401
+// @Security UserAuth
+// @Router /polls [get]
+func (h AdminApisHandler) GetPolls(user *model.User, w http.ResponseWriter, r *http.Request) {
+	filter := model.PollsFilter{
+		UserIDs:  getStringListQueryParam(r, "user_ids"),
+		GroupIDs: getStringListQueryParam(r, "group_ids"),
+		Statuses: getStringListQueryParam(r, "statuses"),
+		Stadiums: getStringListQueryParam(r, "stadiums"),
+		Search:   r.URL.Query().Get("search"),
+		Offset:   getInt64QueryParam(r, "offset"),
+		Limit:    getInt64QueryParam(r, "limit"),
+	}
+	if pin := getInt64QueryParam(r, "pin"); pin != nil {
+		value := int(*pin)
+		filter.Pin = &value
+	}
+
+	resData, err := h.app.Services.GetPolls(user, filter, false)
+	if err != nil {
+		log.Printf("Error on adminapis.GetPolls: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	result := []model.PollResult{}
+	for _, entry := range resData {
+		result = append(result, entry.ToPollResult(entry.UserID))
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		log.Printf("Error on adminapis.GetPolls: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetPoll Retrieves a poll of the organization by id
+// @Description Retrieves a poll of the organization by id regardless of its owner and members
+// @Tags Admin
+// @ID AdminGetPoll
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.PollResult
+// @Failure 401
+// @Failure 404
+// @Security UserAuth
+// @Router /polls/{id} [get]
+func (h AdminApisHandler) GetPoll(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.getPoll(user, id)
+	if err != nil {
+		log.Printf("Error on adminapis.GetPoll(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if resData == nil {
+		log.Printf("Error on adminapis.GetPoll(%s): not found", id)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	data, err := json.Marshal(resData.ToPollResult(resData.UserID))
+	if err != nil {
+		log.Printf("Error on adminapis.GetPoll(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// UpdatePoll Updates a poll of the organization with the specified id
+// @Description Updates a poll of the organization with the specified id regardless of its owner. The status is changed by the poll actions only.
+// @Tags Admin
+// @ID AdminUpdatePoll
+// @Param data body model.Poll true "body json"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.PollResult
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 409
+// @Security UserAuth
+// @Router /polls/{id} [put]
+func (h AdminApisHandler) UpdatePoll(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	pollID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		log.Printf("Error on adminapis.UpdatePoll(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on adminapis.UpdatePoll(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var item model.Poll
+	err = json.Unmarshal(data, &item)
+	if err != nil {
+		log.Printf("Error on adminapis.UpdatePoll(%s): %s", id, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	item.ID = pollID
+
+	resData, err := h.app.Services.UpdatePoll(user, item, true)
+	if err != nil {
+		log.Printf("Error on adminapis.UpdatePoll(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	jsonData, err := json.Marshal(resData.ToPollResult(resData.UserID))
+	if err != nil {
+		log.Printf("Error on adminapis.UpdatePoll(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// DeletePoll Deletes a poll of the organization with the specified id
+// @Description Deletes a poll of the organization with the specified id regardless of its owner
+// @Tags Admin
+// @ID AdminDeletePoll
+// @Success 200
+// @Failure 401
+// @Failure 404
+// @Security UserAuth
+// @Router /polls/{id} [delete]
+func (h AdminApisHandler) DeletePoll(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	err := h.app.Services.DeletePoll(user, id, true)
+	if err != nil {
+		log.Printf("Error on adminapis.DeletePoll(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// EndPoll Force-ends a poll of the organization with the specified id
+// @Description Ends a poll of the organization with the specified id regardless of its owner. The admin is recorded as the actor of the transition.
+// @Tags Admin
+// @ID AdminEndPoll
+// @Success 200
+// @Failure 401
+// @Failure 404
+// @Failure 409
+// @Security UserAuth
+// @Router /polls/{id}/end [put]
+func (h AdminApisHandler) EndPoll(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	err := h.app.Services.EndPoll(user, id, true)
+	if err != nil {
+		log.Printf("Error on adminapis.EndPoll(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// getPoll gets a poll of the organization regardless of its owner and members. Returns nil if the poll does not exist.
+func (h AdminApisHandler) getPoll(user *model.User, id string) (*model.Poll, error) {
+	polls, err := h.app.Services.GetPolls(user, model.PollsFilter{PollIDs: []string{id}}, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(polls) == 0 {
+		return nil, nil
+	}
+	return &polls[0], nil
+}
@@ -72,8 +72,9 @@ func NewBBSApisHandler(app *core.Application, config *model.Config) BBSApisHandl
 // @Description Retrieves  all polls by a filter params
 // @Tags Client
 // @ID GetPolls
+// @Param locale query string false "the preferred locale of the question and options, the Accept-Language header is used if not set"
 // @Param data body model.PollsFilter false "body json for defined poll ids as request body"
-// @Success 200 {array} model.PollResult
+// @Success 200 {array} model.PollResult "model.PollsPage if the cursor is set"
 // @Security UserAuth
 // @Router /polls [get]
 func (h ApisHandler) GetPolls(user *model.User, w http.ResponseWriter, r *http.Request) {
@@ -89,6 +90,11 @@ func (h ApisHandler) GetPolls(user *model.User, w http.ResponseWriter, r *http.R
 		}
 	}
 
+	if filter.Cursor != nil {
+		h.writePollsPage(user, filter, getRequestLocales(r), w, "GetPolls")
+		return
+	}
+
 	resData, err := h.app.Services.GetPolls(user, filter, true)
 	if err != nil {
 		log.Printf("Error on apis.GetPolls(): %s", err)
@@ -96,10 +102,13 @@ func (h ApisHandler) GetPolls(user *model.User, w http.ResponseWriter, r *http.R
 		return
 	}
 
+	locales := getRequestLocales(r)
 	result := []model.PollResult{}
 	if len(resData) > 0 {
 		for _, entry := range resData {
-			result = append(result, entry.ToPollResult(user.Claims.Subject))
+			pollResult := entry.ToPollResult(user.Claims.Subject)
+			pollResult.Localize(locales)
+			result = append(result, pollResult)
 		}
 	}
 
@@ -115,12 +124,41 @@ func (h ApisHandler) GetPolls(user *model.User, w http.ResponseWriter, r *http.R
 	w.Write(data)
 }
 
+// writePollsPage writes a page of the polls with the cursor of the next page
+func (h ApisHandler) writePollsPage(user *model.User, filter model.PollsFilter, locales []string, w http.ResponseWriter, operation string) {
+	resData, nextCursor, err := h.app.Services.GetPollsPage(user, filter, true)
+	if err != nil {
+		log.Printf("Error on apis.%s(): %s", operation, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	page := model.PollsPage{Items: []model.PollResult{}, NextCursor: nextCursor}
+	for _, entry := range resData {
+		pollResult := entry.ToPollResult(user.Claims.Subject)
+		pollResult.Localize(locales)
+		page.Items = append(page.Items, pollResult)
+	}
+
+	data, err := json.Marshal(page)
+	if err != nil {
+		log.Printf("Error on apis.%s(): %s", operation, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
 // LoadPolls Retrieves  all polls by a filter params
 // @Description Retrieves  all polls by a filter params
 // @Tags Client
 // @ID LoadPolls
+// @Param locale query string false "the preferred locale of the question and options, the Accept-Language header is used if not set"
 // @Param data body model.PollsFilter false "body json for defined poll ids as request body"
-// @Success 200 {array} model.PollResult
+// @Success 200 {array} model.PollResult "model.PollsPage if the cursor is set"
 // @Security UserAuth
 // @Router /polls/load [post]
 func (h ApisHandler) LoadPolls(user *model.User, w http.ResponseWriter, r *http.Request) {
@@ -136,6 +174,11 @@ func (h ApisHandler) LoadPolls(user *model.User, w http.ResponseWriter, r *http.
 		}
 	}
 
+	if filter.Cursor != nil {
+		h.writePollsPage(user, filter, getRequestLocales(r), w, "LoadPolls")
+		return
+	}
+
 	resData, err := h.app.Services.GetPolls(user, filter, true)
 	if err != nil {
 		log.Printf("Error on apis.LoadPolls(): %s", err)
@@ -143,10 +186,13 @@ func (h ApisHandler) LoadPolls(user *model.User, w http.ResponseWriter, r *http.
 		return
 	}
 
+	locales := getRequestLocales(r)
 	result := []model.PollResult{}
 	if len(resData) > 0 {
 		for _, entry := range resData {
-			result = append(result, entry.ToPollResult(user.Claims.Subject))
+			pollResult := entry.ToPollResult(user.Claims.Subject)
+			pollResult.Localize(locales)
+			result = append(result, pollResult)
 		}
 	}
 
@@ -166,6 +212,7 @@ func (h ApisHandler) LoadPolls(user *model.User, w http.ResponseWriter, r *http.
 // @Description Retrieves a poll by id
 // @Tags Client
 // @ID GetPoll
+// @Param locale query string false "the preferred locale of the question and options, the Accept-Language header is used if not set"
 // @Accept json
 // @Produce json
 // @Success 200 {object} model.Poll
@@ -189,7 +236,10 @@ func (h ApisHandler) GetPoll(user *model.User, w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	data, err := json.Marshal(resData.ToPollResult(user.Claims.Subject))
+	result := resData.ToPollResult(user.Claims.Subject)
+	result.Localize(getRequestLocales(r))
+
+	data, err := json.Marshal(result)
 	if err != nil {
 		log.Printf("Error on apis.GetPoll(%s): %s", id, err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -201,6 +251,57 @@ func (h ApisHandler) GetPoll(user *model.User, w http.ResponseWriter, r *http.Re
 	w.Write(data)
 }
 
+// GetPollByPin Retrieves the active poll with the specified PIN
+// @Description Retrieves the active poll with the specified PIN
+// @Tags Client
+// @ID GetPollByPin
+// @Param locale query string false "the preferred locale of the question and options, the Accept-Language header is used if not set"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.PollResult
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Security UserAuth
+// @Router /polls/by-pin/{pin} [get]
+func (h ApisHandler) GetPollByPin(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	pinParam := vars["pin"]
+
+	pin, err := strconv.Atoi(pinParam)
+	if err != nil || pin <= 0 || pin > model.MaxPollPin {
+		log.Printf("Error on apis.GetPollByPin(%s): invalid pin", pinParam)
+		http.Error(w, "invalid pin", http.StatusBadRequest)
+		return
+	}
+
+	resData, err := h.app.Services.GetPollByPin(user, pin)
+	if err != nil {
+		log.Printf("Error on apis.GetPollByPin(%d): %s", pin, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if resData == nil {
+		log.Printf("Error on apis.GetPollByPin(%d): not found", pin)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	result := resData.ToPollResult(user.Claims.Subject)
+	result.Localize(getRequestLocales(r))
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		log.Printf("Error on apis.GetPollByPin(%d): %s", pin, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
 // UpdatePoll Updates a reward type with the specified id
 // @Description Updates a reward type with the specified id
 // @Tags Client
@@ -231,10 +332,10 @@ func (h ApisHandler) UpdatePoll(user *model.User, w http.ResponseWriter, r *http
 		return
 	}
 
-	resData, err := h.app.Services.UpdatePoll(user, item)
+	resData, err := h.app.Services.UpdatePoll(user, item, false)
 	if err != nil {
 		log.Printf("Error on apis.UpdatePoll(%s): %s", id, err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
 		return
 	}
 
@@ -279,7 +380,7 @@ func (h ApisHandler) CreatePoll(user *model.User, w http.ResponseWriter, r *http
 	createdItem, err := h.app.Services.CreatePoll(user, item)
 	if err != nil {
 		log.Printf("Error on apis.CreatePoll: %s", err)
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
 		return
 	}
 
@@ -300,16 +401,19 @@ func (h ApisHandler) CreatePoll(user *model.User, w http.ResponseWriter, r *http
 // @Tags Client
 // @ID DeletePoll
 // @Success 200
+// @Failure 401
+// @Failure 403
+// @Failure 404
 // @Security UserAuth
 // @Router /polls/{id} [delete]
 func (h ApisHandler) DeletePoll(user *model.User, w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	err := h.app.Services.DeletePoll(user, id)
+	err := h.app.Services.DeletePoll(user, id, false)
 	if err != nil {
 		log.Printf("Error on apis.DeletePoll(%s): %s", id, err)
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
 		return
 	}
 
@@ -417,12 +521,13 @@ func (h ApisHandler) VotePoll(user *model.User, w http.ResponseWriter, r *http.R
 	if user.Claims.Subject != item.UserID {
 		log.Printf("Error on apis.VotePoll(%s): inconsistent user id", id)
 		http.Error(w, "inconsistent user id", http.StatusBadRequest)
+		return
 	}
 
 	err = h.app.Services.VotePoll(user, id, item)
 	if err != nil {
 		log.Printf("Error on apis.VotePoll(%s): %s", id, err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
 		return
 	}
 
@@ -430,35 +535,35 @@ func (h ApisHandler) VotePoll(user *model.User, w http.ResponseWriter, r *http.R
 	w.WriteHeader(http.StatusOK)
 }
 
-// StartPoll Starts an existing poll with the specified id
-// @Description  Starts an existing poll with the specified id
+// RetractPollVote Retracts the vote of the user for a poll with the specified id
+// @Description  Retracts the vote of the user while the poll is started. The poll must allow vote changes.
 // @Tags Client
-// @ID StartPoll
-// @Accept json
-// @Produce json
+// @ID RetractPollVote
 // @Success 200
+// @Failure 404
+// @Failure 409
 // @Security UserAuth
-// @Router /polls/{id}/start [post]
-func (h ApisHandler) StartPoll(user *model.User, w http.ResponseWriter, r *http.Request) {
+// @Router /polls/{id}/vote [delete]
+func (h ApisHandler) RetractPollVote(user *model.User, w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
 	resData, err := h.app.Services.GetPoll(user, id)
 	if err != nil {
-		log.Printf("Error on apis.StartPoll(%s): %s", id, err)
+		log.Printf("Error on apis.RetractPollVote(%s): %s", id, err)
 		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
 	}
 	if resData == nil {
-		log.Printf("Error on apis.StartPoll(%s): not found", id)
+		log.Printf("Error on apis.RetractPollVote(%s): not found", id)
 		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
 	}
 
-	err = h.app.Services.StartPoll(user, id)
+	err = h.app.Services.RetractPollVote(user, id)
 	if err != nil {
-		log.Printf("Error on apis.StartPoll(%s): %s", id, err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error on apis.RetractPollVote(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
 		return
 	}
 
@@ -466,77 +571,78 @@ func (h ApisHandler) StartPoll(user *model.User, w http.ResponseWriter, r *http.
 	w.WriteHeader(http.StatusOK)
 }
 
-// EndPoll Finishes an existing poll with the specified id
-// @Description  Finishes an existing poll with the specified id
+// ExportPollResults Exports the results of a poll
+// @Description Exports the totals per option and, for the non-anonymous polls, a row per vote. Only the creator of the poll or a group admin can export it.
 // @Tags Client
-// @ID EndPoll
-// @Accept json
+// @ID ExportPollResults
+// @Param format query string false "csv (default) or json"
+// @Produce text/csv
 // @Produce json
 // @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
 // @Security UserAuth
-// @Router /polls/{id}/end [post]
-func (h ApisHandler) EndPoll(user *model.User, w http.ResponseWriter, r *http.Request) {
+// @Router /polls/{id}/results/export [get]
+func (h ApisHandler) ExportPollResults(user *model.User, w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	resData, err := h.app.Services.GetPoll(user, id)
-	if err != nil {
-		log.Printf("Error on apis.EndPoll(%s): %s", id, err)
-		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
-		return
+	format := pollExportFormatCSV
+	if formatParam := getStringQueryParam(r, "format"); formatParam != nil {
+		format = *formatParam
 	}
-	if resData == nil {
-		log.Printf("Error on apis.EndPoll(%s): not found", id)
-		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	if format != pollExportFormatCSV && format != pollExportFormatJSON {
+		log.Printf("Error on apis.ExportPollResults(%s): invalid format %s", id, format)
+		http.Error(w, "format must be csv or json", http.StatusBadRequest)
 		return
 	}
 
-	err = h.app.Services.EndPoll(user, id)
+	writer := newPollResultsWriter(w, format)
+	err := h.app.Services.ExportPollResults(user, id, writer)
 	if err != nil {
-		log.Printf("Error on apis.EndPoll(%s): %s", id, err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error on apis.ExportPollResults(%s): %s", id, err)
+		if !writer.started {
+			http.Error(w, err.Error(), getPollErrorStatus(err))
+		}
+		// the response is already started, it stays incomplete
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
+	err = writer.close()
+	if err != nil {
+		log.Printf("Error on apis.ExportPollResults(%s): %s", id, err)
+	}
 }
 
-// GetSurvey Retrieves a Survey by id
-// @Description Retrieves a Survey by id
+// GetPollTextAnswers Retrieves all text answers of an open text poll for moderation
+// @Description Retrieves all text answers of an open text poll for moderation. Only the creator of the poll or a group admin can moderate it.
 // @Tags Client
-// @ID GetSurvey
+// @ID GetPollTextAnswers
 // @Accept json
 // @Produce json
-// @Success 200 {object} model.Survey
+// @Success 200 {array} model.PollTextAnswer
+// @Failure 400
 // @Failure 401
+// @Failure 403
 // @Security UserAuth
-// @Router /surveys/{id} [get]
-func (h ApisHandler) GetSurvey(user *model.User, w http.ResponseWriter, r *http.Request) {
-
+// @Router /polls/{id}/text-answers [get]
+func (h ApisHandler) GetPollTextAnswers(user *model.User, w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	resData, err := h.app.Services.GetSurvey(user, id)
+	resData, err := h.app.Services.GetPollTextAnswers(user, id)
 	if err != nil {
-		log.Printf("Error on apis.GetSurvey(%s): %s", id, err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	if resData == nil {
-		log.Printf("Error on apis.GetSurvey(%s): not found", id)
-		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		log.Printf("Error on apis.GetPollTextAnswers(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
 		return
 	}
 
 	data, err := json.Marshal(resData)
 	if err != nil {
-
-		log.Printf("Error on apis.GetSurvey(%s): %s", id, err)
+		log.Printf("Error on apis.GetPollTextAnswers(%s): %s", id, err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
-
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -544,137 +650,1169 @@ func (h ApisHandler) GetSurvey(user *model.User, w http.ResponseWriter, r *http.
 	w.Write(data)
 }
 
-// CreateSurvey Create a new survey
-// @Description Create a new survey
+// ModeratePollTextAnswer Approves, hides or pins a text answer of an open text poll
+// @Description Approves, hides or pins a text answer of an open text poll. Only the approved answers appear in the results.
 // @Tags Client
-// @ID CreateSurvey
-// @Param data body model.Survey true "body json"
+// @ID ModeratePollTextAnswer
+// @Param data body model.PollTextModeration true "body json"
 // @Accept json
-// @Success 200 {object} model.Survey
+// @Produce json
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
 // @Security UserAuth
-// @Router /surveys [post]
-func (h ApisHandler) CreateSurvey(user *model.User, w http.ResponseWriter, r *http.Request) {
+// @Router /polls/{id}/text-answers/{answer_id} [put]
+func (h ApisHandler) ModeratePollTextAnswer(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	answerID := vars["answer_id"]
 
 	data, err := io.ReadAll(r.Body)
 	if err != nil {
-		log.Printf("Error on apis.CreateSurvey: %s", err)
+		log.Printf("Error on apis.ModeratePollTextAnswer(%s): %s", id, err)
 		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 		return
 	}
 
-	var item model.Survey
+	var item model.PollTextModeration
 	err = json.Unmarshal(data, &item)
 	if err != nil {
-		log.Printf("Error on apis.CreateSurvey: %s", err)
+		log.Printf("Error on apis.ModeratePollTextAnswer(%s): %s", id, err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	createdItem, err := h.app.Services.CreateSurvey(user, item, false)
+	err = h.app.Services.ModeratePollTextAnswer(user, id, answerID, item)
 	if err != nil {
-		log.Printf("Error on apis.CreateSurvey: %s", err)
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		log.Printf("Error on apis.ModeratePollTextAnswer(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
 		return
 	}
 
-	jsonData, err := json.Marshal(createdItem)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// StartPoll Starts an existing poll with the specified id
+// @Description  Starts a created poll or resumes a paused poll with the specified id
+// @Tags Client
+// @ID StartPoll
+// @Accept json
+// @Produce json
+// @Success 200
+// @Failure 409
+// @Security UserAuth
+// @Router /polls/{id}/start [post]
+func (h ApisHandler) StartPoll(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.GetPoll(user, id)
 	if err != nil {
-		log.Printf("Error on apis.CreateSurvey: %s", err)
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		log.Printf("Error on apis.StartPoll(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if resData == nil {
+		log.Printf("Error on apis.StartPoll(%s): not found", id)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	err = h.app.Services.StartPoll(user, id)
+	if err != nil {
+		log.Printf("Error on apis.StartPoll(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
-	w.Write(jsonData)
 }
 
-// UpdateSurvey Updates a survey type with the specified id
-// @Description Updates a survey type with the specified id
+// EndPoll Finishes an existing poll with the specified id
+// @Description  Finishes an existing poll with the specified id
 // @Tags Client
-// @ID UpdateSurvey
-// @Param data body model.Survey true "body json"
+// @ID EndPoll
 // @Accept json
 // @Produce json
-// @Success 200 {object} model.Survey
-// @Failure 401
+// @Success 200
+// @Failure 409
 // @Security UserAuth
-// @Router /surveys/{id} [put]
-func (h ApisHandler) UpdateSurvey(user *model.User, w http.ResponseWriter, r *http.Request) {
-
+// @Router /polls/{id}/end [post]
+func (h ApisHandler) EndPoll(user *model.User, w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	data, err := io.ReadAll(r.Body)
+	resData, err := h.app.Services.GetPoll(user, id)
 	if err != nil {
-
-		log.Printf("Error on apis.UpdateSurvey(%s): %s", id, err)
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		log.Printf("Error on apis.EndPoll(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if resData == nil {
+		log.Printf("Error on apis.EndPoll(%s): not found", id)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
 	}
 
-	var item model.Survey
-	err = json.Unmarshal(data, &item)
+	err = h.app.Services.EndPoll(user, id, false)
 	if err != nil {
-		log.Printf("Error on apis.UpdateSurvey(%s): %s", id, err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		log.Printf("Error on apis.EndPoll(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
 		return
 	}
 
-	err = h.app.Services.UpdateSurvey(user, item, id, false)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// PausePoll Pauses a started poll with the specified id
+// @Description  Pauses a started poll with the specified id. The poll does not accept votes until it is started again.
+// @Tags Client
+// @ID PausePoll
+// @Accept json
+// @Produce json
+// @Success 200
+// @Failure 409
+// @Security UserAuth
+// @Router /polls/{id}/pause [put]
+func (h ApisHandler) PausePoll(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.GetPoll(user, id)
 	if err != nil {
-		log.Printf("Error on apis.UpdateSurvey(%s): %s", id, err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error on apis.PausePoll(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if resData == nil {
+		log.Printf("Error on apis.PausePoll(%s): not found", id)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
+	}
 
+	err = h.app.Services.PausePoll(user, id)
+	if err != nil {
+		log.Printf("Error on apis.PausePoll(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 }
 
-// DeleteSurvey Deletes a survey with the specified id
-// @Description Deletes a survey with the specified id
+// ReopenPoll Reopens an ended poll with the specified id
+// @Description  Starts an ended poll with the specified id again. The PIN of the poll is reserved again, so it must not be used by another active poll.
 // @Tags Client
-// @ID DeleteSurvey
+// @ID ReopenPoll
+// @Accept json
+// @Produce json
 // @Success 200
+// @Failure 409
 // @Security UserAuth
-// @Router /surveys/{id} [delete]
-func (h ApisHandler) DeleteSurvey(user *model.User, w http.ResponseWriter, r *http.Request) {
+// @Router /polls/{id}/reopen [put]
+func (h ApisHandler) ReopenPoll(user *model.User, w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	err := h.app.Services.DeleteSurvey(user, id, false)
+	resData, err := h.app.Services.GetPoll(user, id)
 	if err != nil {
-		log.Printf("Error on apis.DeleteSurvey(%s): %s", id, err)
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		log.Printf("Error on apis.ReopenPoll(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if resData == nil {
+		log.Printf("Error on apis.ReopenPoll(%s): not found", id)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
+	}
 
+	err = h.app.Services.ReopenPoll(user, id)
+	if err != nil {
+		log.Printf("Error on apis.ReopenPoll(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 }
 
-// GetSurveyResponses retrieves SurveyResponses for the current user
-// @Description Retrieves SurveyResponses for the current user
+// ClonePoll Clones a poll with the specified id
+// @Description Creates a new poll with the question, the options and the settings of the poll with the specified id. The new poll is created, not started. Only the creator of the poll or a group admin can clone it.
 // @Tags Client
-// @ID GetSurveyResponse
+// @ID ClonePoll
+// @Param data body model.PollCloneRequest false "body json"
 // @Accept json
 // @Produce json
-// @Success 200 {array} model.SurveyResponse
-// @Failure 401
+// @Success 200 {object} model.PollResult
+// @Failure 400
+// @Failure 403
+// @Failure 404
 // @Security UserAuth
-// @Router /survey-responses [get]
-func (h ApisHandler) GetSurveyResponses(user *model.User, w http.ResponseWriter, r *http.Request) {
-	surveyIDsRaw := r.URL.Query().Get("survey_ids")
-	var surveyIDs []string
-	if len(surveyIDsRaw) > 0 {
-		surveyIDs = strings.Split(surveyIDsRaw, ",")
-	}
-	surveyTypesRaw := r.URL.Query().Get("survey_types")
-	var surveyTypes []string
-	if len(surveyTypesRaw) > 0 {
+// @Router /polls/{id}/clone [post]
+func (h ApisHandler) ClonePoll(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.GetPoll(user, id)
+	if err != nil {
+		log.Printf("Error on apis.ClonePoll(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if resData == nil {
+		log.Printf("Error on apis.ClonePoll(%s): not found", id)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on apis.ClonePoll(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var request model.PollCloneRequest
+	if len(data) > 0 {
+		err = json.Unmarshal(data, &request)
+		if err != nil {
+			log.Printf("Error on apis.ClonePoll(%s): %s", id, err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	createdItem, err := h.app.Services.ClonePoll(user, id, request)
+	if err != nil {
+		log.Printf("Error on apis.ClonePoll(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	jsonData, err := json.Marshal(createdItem.ToPollResult(user.Claims.Subject))
+	if err != nil {
+		log.Printf("Error on apis.ClonePoll(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// GetPollSeries Retrieves the series of a recurring poll
+// @Description Retrieves the recurring poll and its instances with their results, oldest first, so the results can be compared over time. The id is of the recurring poll or of any of its instances.
+// @Tags Client
+// @ID GetPollSeries
+// @Param locale query string false "the preferred locale of the questions and options, the Accept-Language header is used if not set"
+// @Produce json
+// @Success 200 {object} model.PollSeries
+// @Failure 401
+// @Failure 404
+// @Security UserAuth
+// @Router /polls/{id}/series [get]
+func (h ApisHandler) GetPollSeries(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.GetPollSeries(user, id)
+	if err != nil {
+		log.Printf("Error on apis.GetPollSeries(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	locales := getRequestLocales(r)
+	for i := range resData.Instances {
+		resData.Instances[i].Localize(locales)
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Printf("Error on apis.GetPollSeries(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// AddPollCoOwner Adds a co-owner to a poll
+// @Description Gives the user the same rights to manage the poll as its creator. Only the creator of the poll or a group admin can add co-owners.
+// @Tags Client
+// @ID AddPollCoOwner
+// @Produce json
+// @Success 200 {object} model.PollResult
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Security UserAuth
+// @Router /polls/{id}/co-owners/{user_id} [put]
+func (h ApisHandler) AddPollCoOwner(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	userID := vars["user_id"]
+
+	resData, err := h.app.Services.AddPollCoOwner(user, id, userID)
+	if err != nil {
+		log.Printf("Error on apis.AddPollCoOwner(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	data, err := json.Marshal(resData.ToPollResult(user.Claims.Subject))
+	if err != nil {
+		log.Printf("Error on apis.AddPollCoOwner(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// RemovePollCoOwner Removes a co-owner from a poll
+// @Description Removes the management rights of a co-owner. A co-owner can remove themselves, the other co-owners are removed by the creator of the poll or a group admin.
+// @Tags Client
+// @ID RemovePollCoOwner
+// @Produce json
+// @Success 200 {object} model.PollResult
+// @Failure 401
+// @Failure 403
+// @Security UserAuth
+// @Router /polls/{id}/co-owners/{user_id} [delete]
+func (h ApisHandler) RemovePollCoOwner(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	userID := vars["user_id"]
+
+	resData, err := h.app.Services.RemovePollCoOwner(user, id, userID)
+	if err != nil {
+		log.Printf("Error on apis.RemovePollCoOwner(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	data, err := json.Marshal(resData.ToPollResult(user.Claims.Subject))
+	if err != nil {
+		log.Printf("Error on apis.RemovePollCoOwner(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetPollTemplates Retrieves the poll templates of the user
+// @Description Retrieves the personal poll templates of the user and the templates of the user groups, sorted by name. The correct options of a group quiz template are returned only to its creator and the group admins.
+// @Tags Client
+// @ID GetPollTemplates
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.PollTemplate
+// @Failure 401
+// @Security UserAuth
+// @Router /poll-templates [get]
+func (h ApisHandler) GetPollTemplates(user *model.User, w http.ResponseWriter, r *http.Request) {
+	resData, err := h.app.Services.GetPollTemplates(user)
+	if err != nil {
+		log.Printf("Error on apis.GetPollTemplates: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Printf("Error on apis.GetPollTemplates: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetPollTemplate Retrieves a poll template by id
+// @Description Retrieves a poll template by id. A personal template is available to its creator only, a group template to the group members. The correct options of a group quiz template are returned only to its creator and the group admins.
+// @Tags Client
+// @ID GetPollTemplate
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.PollTemplate
+// @Failure 403
+// @Failure 404
+// @Security UserAuth
+// @Router /poll-templates/{id} [get]
+func (h ApisHandler) GetPollTemplate(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.GetPollTemplate(user, id)
+	if err != nil {
+		log.Printf("Error on apis.GetPollTemplate(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Printf("Error on apis.GetPollTemplate(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// CreatePollTemplate Creates a poll template
+// @Description Creates a poll template. A template with a group is available to the group members.
+// @Tags Client
+// @ID CreatePollTemplate
+// @Param data body model.PollTemplate true "body json"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.PollTemplate
+// @Failure 400
+// @Failure 403
+// @Security UserAuth
+// @Router /poll-templates [post]
+func (h ApisHandler) CreatePollTemplate(user *model.User, w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on apis.CreatePollTemplate: %s", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var item model.PollTemplate
+	err = json.Unmarshal(data, &item)
+	if err != nil {
+		log.Printf("Error on apis.CreatePollTemplate: %s", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	createdItem, err := h.app.Services.CreatePollTemplate(user, item)
+	if err != nil {
+		log.Printf("Error on apis.CreatePollTemplate: %s", err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	jsonData, err := json.Marshal(createdItem)
+	if err != nil {
+		log.Printf("Error on apis.CreatePollTemplate: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// UpdatePollTemplate Updates a poll template with the specified id
+// @Description Updates the name and the poll content of a poll template with the specified id. Only the creator of the template or a group admin can update it.
+// @Tags Client
+// @ID UpdatePollTemplate
+// @Param data body model.PollTemplate true "body json"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.PollTemplate
+// @Failure 400
+// @Failure 403
+// @Failure 404
+// @Security UserAuth
+// @Router /poll-templates/{id} [put]
+func (h ApisHandler) UpdatePollTemplate(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on apis.UpdatePollTemplate(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var item model.PollTemplate
+	err = json.Unmarshal(data, &item)
+	if err != nil {
+		log.Printf("Error on apis.UpdatePollTemplate(%s): %s", id, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resData, err := h.app.Services.UpdatePollTemplate(user, id, item)
+	if err != nil {
+		log.Printf("Error on apis.UpdatePollTemplate(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	jsonData, err := json.Marshal(resData)
+	if err != nil {
+		log.Printf("Error on apis.UpdatePollTemplate(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// DeletePollTemplate Deletes a poll template with the specified id
+// @Description Deletes a poll template with the specified id. The polls created from the template are kept. Only the creator of the template or a group admin can delete it.
+// @Tags Client
+// @ID DeletePollTemplate
+// @Success 200
+// @Failure 403
+// @Failure 404
+// @Security UserAuth
+// @Router /poll-templates/{id} [delete]
+func (h ApisHandler) DeletePollTemplate(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	err := h.app.Services.DeletePollTemplate(user, id)
+	if err != nil {
+		log.Printf("Error on apis.DeletePollTemplate(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetPollSessions Retrieves the poll sessions presented by the user
+// @Description Retrieves the poll sessions presented by the user, the newest first
+// @Tags Client
+// @ID GetPollSessions
+// @Produce json
+// @Success 200 {array} model.PollSession
+// @Failure 401
+// @Security UserAuth
+// @Router /poll-sessions [get]
+func (h ApisHandler) GetPollSessions(user *model.User, w http.ResponseWriter, r *http.Request) {
+	resData, err := h.app.Services.GetPollSessions(user)
+	if err != nil {
+		log.Printf("Error on apis.GetPollSessions: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Printf("Error on apis.GetPollSessions: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetPollSession Retrieves a poll session by id
+// @Description Retrieves a poll session by id
+// @Tags Client
+// @ID GetPollSession
+// @Produce json
+// @Success 200 {object} model.PollSession
+// @Failure 404
+// @Security UserAuth
+// @Router /poll-sessions/{id} [get]
+func (h ApisHandler) GetPollSession(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.GetPollSession(user, id)
+	if err != nil {
+		log.Printf("Error on apis.GetPollSession(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Printf("Error on apis.GetPollSession(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetPollSessionByPin Retrieves the poll session with the specified PIN
+// @Description Retrieves the poll session with the specified PIN to join it. The PINs are unique among the sessions of the organization which have not ended.
+// @Tags Client
+// @ID GetPollSessionByPin
+// @Produce json
+// @Success 200 {object} model.PollSession
+// @Failure 400
+// @Failure 404
+// @Security UserAuth
+// @Router /poll-sessions/by-pin/{pin} [get]
+func (h ApisHandler) GetPollSessionByPin(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	pinParam := vars["pin"]
+
+	pin, err := strconv.Atoi(pinParam)
+	if err != nil || pin <= 0 || pin > model.MaxPollPin {
+		log.Printf("Error on apis.GetPollSessionByPin(%s): invalid pin", pinParam)
+		http.Error(w, "invalid pin", http.StatusBadRequest)
+		return
+	}
+
+	resData, err := h.app.Services.GetPollSessionByPin(user, pin)
+	if err != nil {
+		log.Printf("Error on apis.GetPollSessionByPin(%d): %s", pin, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Printf("Error on apis.GetPollSessionByPin(%d): %s", pin, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// CreatePollSession Creates a poll session
+// @Description Creates a poll session of ordered polls presented by the user. The user must be able to manage the polls.
+// @Tags Client
+// @ID CreatePollSession
+// @Param data body model.PollSession true "body json"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.PollSession
+// @Failure 400
+// @Failure 403
+// @Failure 409
+// @Security UserAuth
+// @Router /poll-sessions [post]
+func (h ApisHandler) CreatePollSession(user *model.User, w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on apis.CreatePollSession: %s", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var item model.PollSession
+	err = json.Unmarshal(data, &item)
+	if err != nil {
+		log.Printf("Error on apis.CreatePollSession: %s", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	createdItem, err := h.app.Services.CreatePollSession(user, item)
+	if err != nil {
+		log.Printf("Error on apis.CreatePollSession: %s", err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	jsonData, err := json.Marshal(createdItem)
+	if err != nil {
+		log.Printf("Error on apis.CreatePollSession: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// UpdatePollSession Updates a poll session with the specified id
+// @Description Updates the title, the polls and the PIN of a poll session with the specified id. Only the presenter can update it.
+// @Tags Client
+// @ID UpdatePollSession
+// @Param data body model.PollSession true "body json"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.PollSession
+// @Failure 400
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Security UserAuth
+// @Router /poll-sessions/{id} [put]
+func (h ApisHandler) UpdatePollSession(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on apis.UpdatePollSession(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var item model.PollSession
+	err = json.Unmarshal(data, &item)
+	if err != nil {
+		log.Printf("Error on apis.UpdatePollSession(%s): %s", id, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resData, err := h.app.Services.UpdatePollSession(user, id, item)
+	if err != nil {
+		log.Printf("Error on apis.UpdatePollSession(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	jsonData, err := json.Marshal(resData)
+	if err != nil {
+		log.Printf("Error on apis.UpdatePollSession(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// DeletePollSession Deletes a poll session with the specified id
+// @Description Deletes a poll session with the specified id. The polls are kept. Only the presenter can delete it.
+// @Tags Client
+// @ID DeletePollSession
+// @Success 200
+// @Failure 403
+// @Failure 404
+// @Security UserAuth
+// @Router /poll-sessions/{id} [delete]
+func (h ApisHandler) DeletePollSession(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	err := h.app.Services.DeletePollSession(user, id)
+	if err != nil {
+		log.Printf("Error on apis.DeletePollSession(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// AdvancePollSession Advances a poll session to the next poll
+// @Description Makes the next poll of the session live. The previous poll is ended and the next one is started. The session ends when it is advanced past the last poll. Only the presenter can advance it.
+// @Tags Client
+// @ID AdvancePollSession
+// @Produce json
+// @Success 200 {object} model.PollSession
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Security UserAuth
+// @Router /poll-sessions/{id}/next [put]
+func (h ApisHandler) AdvancePollSession(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.AdvancePollSession(user, id)
+	if err != nil {
+		log.Printf("Error on apis.AdvancePollSession(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Printf("Error on apis.AdvancePollSession(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// EndPollSession Ends a poll session
+// @Description Ends a poll session and its live poll. Only the presenter can end it.
+// @Tags Client
+// @ID EndPollSession
+// @Produce json
+// @Success 200 {object} model.PollSession
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Security UserAuth
+// @Router /poll-sessions/{id}/end [put]
+func (h ApisHandler) EndPollSession(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.EndPollSession(user, id)
+	if err != nil {
+		log.Printf("Error on apis.EndPollSession(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Printf("Error on apis.EndPollSession(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetPollSessionEvents Subscribes to a poll session events as SSE
+// @Description Subscribes to a poll session events as SSE. The live poll is announced on subscribe and on every change.
+// @Tags Client
+// @ID GetPollSessionEvents
+// @Produce json
+// @Success 200
+// @Security UserAuth
+// @Router /poll-sessions/{id}/events [get]
+func (h ApisHandler) GetPollSessionEvents(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	session, err := h.app.Services.GetPollSession(user, id)
+	if err != nil {
+		log.Printf("Error on apis.GetPollSessionEvents(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+	if session.Status == model.PollSessionStatusEnded {
+		log.Printf("Error on apis.GetPollSessionEvents(%s): the session has ended", id)
+		http.Error(w, "the session has ended", http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Connection doesn't support streaming", http.StatusBadRequest)
+		return
+	}
+
+	resultChan := make(chan map[string]interface{})
+
+	err = h.app.Services.SubscribeToPollSession(user, session, resultChan)
+	if err != nil {
+		log.Printf("Error on apis.GetPollSessionEvents(%s): %s", id, err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	for open := true; open; {
+		select {
+		case data, ok := <-resultChan:
+			if ok {
+				jsonData, err := json.Marshal(data)
+				if err != nil {
+					log.Printf("Error on apis.GetPollSessionEvents(): %s", err)
+				}
+				w.Write(jsonData)
+			}
+			flusher.Flush()
+			open = ok
+		case <-r.Context().Done():
+			//the client has disconnected, the stream is drained until the session server closes it
+			h.app.Services.UnsubscribeFromPollSession(user, session, resultChan)
+			for range resultChan {
+			}
+			open = false
+		}
+	}
+	log.Printf("closing event stream for user %s and poll session %s", user.Claims.Subject, id)
+}
+
+// GetQuizLeaderboard Retrieves the quiz leaderboard of a group or a session
+// @Description Retrieves the scores of the users across the ended quiz polls of a group or a session, the highest score first
+// @Tags Client
+// @ID GetQuizLeaderboard
+// @Param group_id query string false "the group of the quiz polls"
+// @Param session_id query string false "the session of the quiz polls"
+// @Param limit query integer false "the max number of entries"
+// @Produce json
+// @Success 200 {object} model.QuizLeaderboard
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Security UserAuth
+// @Router /quiz-leaderboard [get]
+func (h ApisHandler) GetQuizLeaderboard(user *model.User, w http.ResponseWriter, r *http.Request) {
+	groupID := getStringQueryParam(r, "group_id")
+	sessionID := ""
+	if value := getStringQueryParam(r, "session_id"); value != nil {
+		sessionID = *value
+	}
+	limit := getIntQueryParam(r, "limit", 0)
+
+	resData, err := h.app.Services.GetQuizLeaderboard(user, groupID, sessionID, limit)
+	if err != nil {
+		log.Printf("Error on apis.GetQuizLeaderboard: %s", err)
+		http.Error(w, err.Error(), getPollErrorStatus(err))
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Printf("Error on apis.GetQuizLeaderboard: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetSurveys Retrieves the surveys by filter params
+// @Description Retrieves the surveys of the app by filter params. The surveys matching the search are sorted by relevance, the other ones newest first.
+// @Tags Client
+// @ID ClientGetSurveys
+// @Param search query string false "Full-text search over the title and the questions"
+// @Param types query string false "Comma separated survey types"
+// @Param offset query integer false "Offset"
+// @Param limit query integer false "Limit"
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.Survey
+// @Failure 401
+// @Security UserAuth
+// @Router /surveys [get]
+func (h ApisHandler) GetSurveys(user *model.User, w http.ResponseWriter, r *http.Request) {
+	resData, err := h.app.Services.GetSurveys(user, getSurveysFilter(r))
+	if err != nil {
+		log.Printf("Error on apis.GetSurveys: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+		log.Printf("Error on apis.GetSurveys: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetSurvey Retrieves a Survey by id
+// @Description Retrieves a Survey by id
+// @Tags Client
+// @ID GetSurvey
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.Survey
+// @Failure 401
+// @Security UserAuth
+// @Router /surveys/{id} [get]
+func (h ApisHandler) GetSurvey(user *model.User, w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	resData, err := h.app.Services.GetSurvey(user, id)
+	if err != nil {
+		log.Printf("Error on apis.GetSurvey(%s): %s", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if resData == nil {
+		log.Printf("Error on apis.GetSurvey(%s): not found", id)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	data, err := json.Marshal(resData)
+	if err != nil {
+
+		log.Printf("Error on apis.GetSurvey(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// CreateSurvey Create a new survey
+// @Description Create a new survey
+// @Tags Client
+// @ID CreateSurvey
+// @Param data body model.Survey true "body json"
+// @Accept json
+// @Success 200 {object} model.Survey
+// @Security UserAuth
+// @Router /surveys [post]
+func (h ApisHandler) CreateSurvey(user *model.User, w http.ResponseWriter, r *http.Request) {
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on apis.CreateSurvey: %s", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var item model.Survey
+	err = json.Unmarshal(data, &item)
+	if err != nil {
+		log.Printf("Error on apis.CreateSurvey: %s", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	createdItem, err := h.app.Services.CreateSurvey(user, item, false)
+	if err != nil {
+		log.Printf("Error on apis.CreateSurvey: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	jsonData, err := json.Marshal(createdItem)
+	if err != nil {
+		log.Printf("Error on apis.CreateSurvey: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// UpdateSurvey Updates a survey type with the specified id
+// @Description Updates a survey type with the specified id
+// @Tags Client
+// @ID UpdateSurvey
+// @Param data body model.Survey true "body json"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.Survey
+// @Failure 401
+// @Security UserAuth
+// @Router /surveys/{id} [put]
+func (h ApisHandler) UpdateSurvey(user *model.User, w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+
+		log.Printf("Error on apis.UpdateSurvey(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var item model.Survey
+	err = json.Unmarshal(data, &item)
+	if err != nil {
+		log.Printf("Error on apis.UpdateSurvey(%s): %s", id, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.app.Services.UpdateSurvey(user, item, id, false)
+	if err != nil {
+		log.Printf("Error on apis.UpdateSurvey(%s): %s", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteSurvey Deletes a survey with the specified id
+// @Description Deletes a survey with the specified id
+// @Tags Client
+// @ID DeleteSurvey
+// @Success 200
+// @Security UserAuth
+// @Router /surveys/{id} [delete]
+func (h ApisHandler) DeleteSurvey(user *model.User, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	err := h.app.Services.DeleteSurvey(user, id, false)
+	if err != nil {
+		log.Printf("Error on apis.DeleteSurvey(%s): %s", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetSurveyResponses retrieves SurveyResponses for the current user
+// @Description Retrieves SurveyResponses for the current user
+// @Tags Client
+// @ID GetSurveyResponse
+// @Accept json
+// @Produce json
+// @Param cursor query string false "The next_cursor of the previous page, empty for the first page. The response is a model.SurveyResponsesPage if it is set."
+// @Success 200 {array} model.SurveyResponse
+// @Failure 400
+// @Failure 401
+// @Security UserAuth
+// @Router /survey-responses [get]
+func (h ApisHandler) GetSurveyResponses(user *model.User, w http.ResponseWriter, r *http.Request) {
+	surveyIDsRaw := r.URL.Query().Get("survey_ids")
+	var surveyIDs []string
+	if len(surveyIDsRaw) > 0 {
+		surveyIDs = strings.Split(surveyIDsRaw, ",")
+	}
+	surveyTypesRaw := r.URL.Query().Get("survey_types")
+	var surveyTypes []string
+	if len(surveyTypesRaw) > 0 {
 		surveyTypes = strings.Split(surveyTypesRaw, ",")
 	}
 	startDateRaw := r.URL.Query().Get("start_date")
@@ -727,6 +1865,30 @@ func (h ApisHandler) GetSurveyResponses(user *model.User, w http.ResponseWriter,
 		offset = intParsed
 	}
 
+	if r.URL.Query().Has("cursor") {
+		items, nextCursor, err := h.app.Services.GetSurveyResponsesPage(user, surveyIDs, surveyTypes, startDate, endDate, limit, r.URL.Query().Get("cursor"))
+		if err != nil {
+			log.Printf("Error on apis.GetSurveyResponses: %s", err)
+			http.Error(w, err.Error(), getPollErrorStatus(err))
+			return
+		}
+		if items == nil {
+			items = []model.SurveyResponse{}
+		}
+
+		data, err := json.Marshal(model.SurveyResponsesPage{Items: items, NextCursor: nextCursor})
+		if err != nil {
+			log.Printf("Error on apis.GetSurveyResponses: %s", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+		return
+	}
+
 	resData, err := h.app.Services.GetSurveyResponses(user, surveyIDs, surveyTypes, startDate, endDate, &limit, &offset)
 	if err != nil {
 		log.Printf("Error on apis.GetSurveyResponses: %s", err)
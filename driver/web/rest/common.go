@@ -15,8 +15,12 @@
 package rest
 
 import (
+	"errors"
 	"net/http"
+	"polls/core/model"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 func getStringQueryParam(r *http.Request, paramName string) *string {
@@ -28,6 +32,14 @@ func getStringQueryParam(r *http.Request, paramName string) *string {
 	return nil
 }
 
+func getStringListQueryParam(r *http.Request, paramName string) []string {
+	value := getStringQueryParam(r, paramName)
+	if value == nil {
+		return nil
+	}
+	return strings.Split(*value, ",")
+}
+
 func getInt64QueryParam(r *http.Request, paramName string) *int64 {
 	params, ok := r.URL.Query()[paramName]
 	if ok && len(params[0]) > 0 {
@@ -62,3 +74,78 @@ func getBoolQueryParam(r *http.Request, paramName string, defaultValue bool) boo
 	}
 	return defaultValue
 }
+
+// getSurveysFilter gets the surveys filter from the query params
+func getSurveysFilter(r *http.Request) model.SurveysFilter {
+	filter := model.SurveysFilter{
+		Types:  getStringListQueryParam(r, "types"),
+		Offset: getInt64QueryParam(r, "offset"),
+		Limit:  getInt64QueryParam(r, "limit"),
+	}
+	if search := getStringQueryParam(r, "search"); search != nil {
+		filter.Search = *search
+	}
+	return filter
+}
+
+// getRequestLocales gets the preferred locales of the request, most preferred first. The locale query param takes
+// precedence over the Accept-Language header.
+func getRequestLocales(r *http.Request) []string {
+	if locale := getStringQueryParam(r, "locale"); locale != nil {
+		return []string{*locale}
+	}
+
+	type weightedLocale struct {
+		locale  string
+		quality float64
+	}
+	var weighted []weightedLocale
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale = strings.TrimSpace(locale)
+		if len(locale) == 0 || locale == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			value, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = value
+		}
+		if quality > 0 {
+			weighted = append(weighted, weightedLocale{locale: locale, quality: quality})
+		}
+	}
+	sort.SliceStable(weighted, func(i, j int) bool { return weighted[i].quality > weighted[j].quality })
+
+	locales := make([]string, len(weighted))
+	for i, entry := range weighted {
+		locales[i] = entry.locale
+	}
+	return locales
+}
+
+// getPollErrorStatus maps the model errors to http status codes
+func getPollErrorStatus(err error) int {
+	if errors.Is(err, model.ErrInvalidPoll) || errors.Is(err, model.ErrInvalidVote) || errors.Is(err, model.ErrInvalidStadium) ||
+		errors.Is(err, model.ErrInvalidPollTemplate) || errors.Is(err, model.ErrInvalidCursor) ||
+		errors.Is(err, model.ErrInvalidLeaderboardScope) || errors.Is(err, model.ErrInvalidPollSession) {
+		return http.StatusBadRequest
+	}
+	if errors.Is(err, model.ErrPollNotStarted) || errors.Is(err, model.ErrAlreadyVoted) || errors.Is(err, model.ErrPollPinInUse) ||
+		errors.Is(err, model.ErrStadiumExists) || errors.Is(err, model.ErrVoteChangeNotAllowed) || errors.Is(err, model.ErrInvalidPollTransition) ||
+		errors.Is(err, model.ErrPollVoteLimitReached) || errors.Is(err, model.ErrPollChanged) {
+		return http.StatusConflict
+	}
+	if errors.Is(err, model.ErrPollPermission) || errors.Is(err, model.ErrOutsideGeoFence) {
+		return http.StatusForbidden
+	}
+	if errors.Is(err, model.ErrTextAnswerNotFound) || errors.Is(err, model.ErrStadiumNotFound) ||
+		errors.Is(err, model.ErrPollNotFound) || errors.Is(err, model.ErrVoteNotFound) || errors.Is(err, model.ErrPollTemplateNotFound) || errors.Is(err, model.ErrPollSessionNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,178 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"polls/core/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	pollExportFormatCSV  = "csv"
+	pollExportFormatJSON = "json"
+
+	// the exported rows are flushed to the client after every batch
+	pollExportFlushRows = 100
+)
+
+// pollResultsWriter streams the exported poll results to the response. Nothing is written before the results,
+// so an error before that can still be returned with a proper status.
+type pollResultsWriter struct {
+	w         http.ResponseWriter
+	format    string
+	started   bool
+	anonymous bool
+	rows      int
+
+	csv       *csv.Writer
+	jsonVotes int
+}
+
+func newPollResultsWriter(w http.ResponseWriter, format string) *pollResultsWriter {
+	return &pollResultsWriter{w: w, format: format}
+}
+
+// WriteResult writes the poll details and the totals per option
+func (pw *pollResultsWriter) WriteResult(result model.PollResult) error {
+	pw.started = true
+	pw.anonymous = result.Anonymous
+	totals := result.OptionTotals()
+
+	filename := fmt.Sprintf("poll-%s-results.%s", result.ID.Hex(), pw.format)
+	pw.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+
+	if pw.format == pollExportFormatCSV {
+		pw.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		pw.w.WriteHeader(http.StatusOK)
+
+		pw.csv = csv.NewWriter(pw.w)
+		records := [][]string{
+			{"question", result.Question},
+			{"unique_voters_count", strconv.Itoa(result.UniqueVotersCount)},
+			{"total", strconv.Itoa(result.Total)},
+			{},
+			{"option", "count"},
+		}
+		for _, total := range totals {
+			records = append(records, []string{total.Option, strconv.Itoa(total.Count)})
+		}
+		if !result.Anonymous {
+			records = append(records, []string{}, []string{"user_id", "answer", "value", "text", "status", "created"})
+		}
+		for _, record := range records {
+			err := pw.csv.Write(record)
+			if err != nil {
+				return err
+			}
+		}
+		return pw.flush()
+	}
+
+	pw.w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	pw.w.WriteHeader(http.StatusOK)
+
+	header := map[string]interface{}{
+		"poll_id":             result.ID.Hex(),
+		"question":            result.Question,
+		"poll_type":           result.PollType,
+		"unique_voters_count": result.UniqueVotersCount,
+		"total":               result.Total,
+		"totals":              totals,
+	}
+	data, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	//leave the object open for the votes
+	_, err = pw.w.Write(data[:len(data)-1])
+	if err != nil {
+		return err
+	}
+	if !result.Anonymous {
+		_, err = pw.w.Write([]byte(`,"votes":[`))
+		if err != nil {
+			return err
+		}
+	}
+	return pw.flush()
+}
+
+// WriteVote writes a vote row
+func (pw *pollResultsWriter) WriteVote(vote model.PollExportVote) error {
+	if pw.format == pollExportFormatCSV {
+		value := ""
+		if vote.Value != nil {
+			value = strconv.FormatFloat(*vote.Value, 'f', -1, 64)
+		}
+		err := pw.csv.Write([]string{vote.UserID, strings.Join(vote.Answer, ";"), value, vote.Text, vote.Status, vote.Created.Format(time.RFC3339)})
+		if err != nil {
+			return err
+		}
+	} else {
+		data, err := json.Marshal(vote)
+		if err != nil {
+			return err
+		}
+		if pw.jsonVotes > 0 {
+			data = append([]byte(","), data...)
+		}
+		_, err = pw.w.Write(data)
+		if err != nil {
+			return err
+		}
+		pw.jsonVotes++
+	}
+
+	pw.rows++
+	if pw.rows%pollExportFlushRows == 0 {
+		return pw.flush()
+	}
+	return nil
+}
+
+// close completes the exported document
+func (pw *pollResultsWriter) close() error {
+	if pw.format == pollExportFormatJSON {
+		closing := "}"
+		if !pw.anonymous {
+			closing = "]}"
+		}
+		_, err := pw.w.Write([]byte(closing))
+		if err != nil {
+			return err
+		}
+	}
+	return pw.flush()
+}
+
+func (pw *pollResultsWriter) flush() error {
+	if pw.csv != nil {
+		pw.csv.Flush()
+		err := pw.csv.Error()
+		if err != nil {
+			return err
+		}
+	}
+	if flusher, ok := pw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}